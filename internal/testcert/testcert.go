@@ -0,0 +1,40 @@
+// Package testcert generates throwaway signing keys and certificates for the tests of the nested modules,
+// so that no private key is copied into them.
+package testcert
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+)
+
+// NotAfter is the expiration time of the generated certificates.
+var NotAfter = time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// New returns a PEM encoded RSA key and a self-signed certificate for it. It panics on failure, since it is
+// meant to initialize test variables.
+func New() (keyPEM, certPEM []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test License Signing"},
+		NotBefore:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              NotAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	return keyPEM, certPEM
+}
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// HostFingerprint binds a license to a host. Factors are the hashes of host identifiers keyed by factor name,
+// as computed by the fingerprint package, and MinMatches is how many of them must match the host. Every factor
+// must match when MinMatches is zero.
+type HostFingerprint struct {
+	Factors    map[string]string `json:"Factors,omitempty"`
+	MinMatches int               `json:"MinMatches,omitempty"`
+}
+
+func (h *HostFingerprint) Validate() error {
+	if len(h.Factors) == 0 {
+		return errors.New("host fingerprint has no factors")
+	}
+	for name, hash := range h.Factors {
+		if name == "" || hash == "" {
+			return errors.New("host fingerprint has an empty factor")
+		}
+	}
+	if h.MinMatches < 0 || h.MinMatches > len(h.Factors) {
+		return fmt.Errorf("host fingerprint requires %d matches of %d factors", h.MinMatches, len(h.Factors))
+	}
+	return nil
+}
+
+// FactorNames returns the sorted names of the factors.
+func (h *HostFingerprint) FactorNames() []string {
+	return slices.Sorted(maps.Keys(h.Factors))
+}
+
+// Match checks the hashes computed on the current host against the fingerprint. Factors missing from current
+// do not match.
+func (h *HostFingerprint) Match(current map[string]string) error {
+	if err := h.Validate(); err != nil {
+		return err
+	}
+
+	matches := 0
+	for name, expected := range h.Factors {
+		actual, ok := current[name]
+		if ok && subtle.ConstantTimeCompare([]byte(actual), []byte(expected)) == 1 {
+			matches++
+		}
+	}
+
+	required := h.MinMatches
+	if required == 0 {
+		required = len(h.Factors)
+	}
+	if matches < required {
+		return fmt.Errorf("host fingerprint matches %d of %d factors, %d required", matches, len(h.Factors), required)
+	}
+	return nil
+}
+
+func (h *HostFingerprint) Clone() *HostFingerprint {
+	if h == nil {
+		return nil
+	}
+	return &HostFingerprint{
+		Factors:    maps.Clone(h.Factors),
+		MinMatches: h.MinMatches,
+	}
+}
@@ -0,0 +1,51 @@
+package common
+
+import "context"
+
+// Operation names a license operation reported to an Instrumentation.
+type Operation string
+
+const (
+	OperationValidateLicense     Operation = "license.validate"
+	OperationValidateCertificate Operation = "license.validate_certificate"
+	OperationGenerateLicense     Operation = "license.generate"
+	OperationRenewLicense        Operation = "license.renew"
+)
+
+// OperationInfo describes the license an operation worked on. It never carries license contents or signatures.
+type OperationInfo struct {
+	LicenseID           string
+	OrganizationID      string
+	ProductPlanUniqueID string
+	// Reason is the failure reason code of a failed operation.
+	Reason string
+}
+
+// NewOperationInfo describes the license, which may be nil.
+func NewOperationInfo(license *License) OperationInfo {
+	if license == nil {
+		return OperationInfo{}
+	}
+	return OperationInfo{
+		LicenseID:           license.ID,
+		OrganizationID:      license.OrganizationID,
+		ProductPlanUniqueID: license.ProductPlanUniqueID,
+	}
+}
+
+// OperationEnd completes an operation started by an Instrumentation.
+type OperationEnd func(info OperationInfo, err error)
+
+// Instrumentation observes license operations, such as to emit traces and metrics. The telemetry package
+// provides an OpenTelemetry implementation.
+type Instrumentation interface {
+	Start(ctx context.Context, operation Operation) (context.Context, OperationEnd)
+}
+
+// StartOperation starts the operation on the instrumentation, which may be nil.
+func StartOperation(ctx context.Context, instrumentation Instrumentation, operation Operation) (context.Context, OperationEnd) {
+	if instrumentation == nil {
+		return ctx, func(OperationInfo, error) {}
+	}
+	return instrumentation.Start(ctx, operation)
+}
@@ -1,8 +1,14 @@
 package common
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +25,30 @@ type License struct {
 	ProductPlanUniqueID string `json:"ProductPlanUniqueID,omitempty"`
 	OrganizationID      string `json:"OrganizationID,omitempty"`
 	Version             uint64 `json:"Version,omitempty"`
+
+	// OriginalIssueTime is the creation time of the first version of the license. It is preserved across renewals.
+	OriginalIssueTime string `json:"OriginalIssueTime,omitempty"`
+	// PreviousSignatureHash links a renewed license to the signature of the version it replaces.
+	PreviousSignatureHash string `json:"PreviousSignatureHash,omitempty"`
+
+	// LineageID is shared by every license derived from the same subscription through amendments.
+	// Licenses without a lineage use their own ID.
+	LineageID string `json:"LineageID,omitempty"`
+	// SupersededLicenseID is the ID of the license replaced by an amendment.
+	SupersededLicenseID string `json:"SupersededLicenseID,omitempty"`
+
+	// InstanceIDs binds the license to several instances, in addition to InstanceID. Entries are instance IDs
+	// or path.Match patterns such as "instance-dr-*".
+	InstanceIDs []string `json:"InstanceIDs,omitempty"`
+	// HostFingerprint binds the license to a host, for node-locked licenses.
+	HostFingerprint *HostFingerprint `json:"HostFingerprint,omitempty"`
+
+	Features []string         `json:"Features,omitempty"`
+	Limits   map[string]int64 `json:"Limits,omitempty"`
+
+	// NotBefore is the time the license becomes usable. Licenses without it are usable from creation.
+	NotBefore string            `json:"NotBefore,omitempty"`
+	Metadata  map[string]string `json:"Metadata,omitempty"`
 }
 
 func NewLicense(orgID, productPlanUniqueID, instanceID, subscriptionID, description string, creationTime, expirationTime time.Time) *License {
@@ -43,18 +73,56 @@ func (l *License) GetCreationTime() (time.Time, error) {
 	return time.Parse(time.RFC3339, l.CreationTime)
 }
 
+// GetNotBefore returns the time the license becomes usable, or the zero time when it is not set.
+func (l *License) GetNotBefore() (time.Time, error) {
+	if l.NotBefore == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, l.NotBefore)
+}
+
+// GetOriginalIssueTime returns the time the first version of the license was issued.
+// Licenses that were never renewed fall back to their creation time.
+func (l *License) GetOriginalIssueTime() (time.Time, error) {
+	if l.OriginalIssueTime == "" {
+		return l.GetCreationTime()
+	}
+	return time.Parse(time.RFC3339, l.OriginalIssueTime)
+}
+
+func (l *License) GetLineageID() string {
+	if l.LineageID == "" {
+		return l.ID
+	}
+	return l.LineageID
+}
+
+func (l *License) HasFeature(feature string) bool {
+	return slices.Contains(l.Features, feature)
+}
+
+func (l *License) GetLimit(name string) (int64, bool) {
+	limit, ok := l.Limits[name]
+	return limit, ok
+}
+
 func (l *License) IsValid(orgID, productPlanUniqueID, instanceID string) error {
+	return l.IsValidWithPolicy(orgID, productPlanUniqueID, instanceID, nil)
+}
+
+// IsValidWithPolicy is IsValid, matching the organization, plan and instance according to the policy.
+func (l *License) IsValidWithPolicy(orgID, productPlanUniqueID, instanceID string, policy *MatchPolicy) error {
 	if l.ID == "" || l.CreationTime == "" || l.ExpirationTime == "" {
 		return errors.New("missing required fields")
 	}
-	if orgID != "" && l.OrganizationID != orgID {
-		return fmt.Errorf("invalid organization id %s, expected %s", orgID, l.OrganizationID)
+	if err := policy.matchIdentity(IdentityFieldOrganization, "organization id", l.OrganizationID, policy.organizationIDs(orgID)); err != nil {
+		return err
 	}
-	if productPlanUniqueID != "" && l.ProductPlanUniqueID != productPlanUniqueID {
-		return fmt.Errorf("invalid product unique id %s, expected %s", productPlanUniqueID, l.ProductPlanUniqueID)
+	if err := policy.matchIdentity(IdentityFieldProductPlan, "product unique id", l.ProductPlanUniqueID, policy.productPlanUniqueIDs(productPlanUniqueID)); err != nil {
+		return err
 	}
-	if instanceID != "" && l.InstanceID != instanceID {
-		return fmt.Errorf("invalid instance id: %s, expected %s", instanceID, l.InstanceID)
+	if err := l.matchInstance(instanceID, policy); err != nil {
+		return err
 	}
 	if _, err := l.GetCreationTime(); err != nil {
 		return errors.Wrap(err, "invalid creation time")
@@ -62,6 +130,58 @@ func (l *License) IsValid(orgID, productPlanUniqueID, instanceID string) error {
 	if _, err := l.GetExpirationTime(); err != nil {
 		return errors.Wrap(err, "invalid expiration time")
 	}
+	if _, err := l.GetNotBefore(); err != nil {
+		return errors.Wrap(err, "invalid not before time")
+	}
+	return nil
+}
+
+// MatchesInstance reports whether the license is bound to the instance, either by InstanceID or by one of the
+// InstanceIDs. It fails when any of the InstanceIDs is a malformed pattern, whatever the instance.
+func (l *License) MatchesInstance(instanceID string) (bool, error) {
+	if err := ValidateInstanceIDPatterns(l.InstanceIDs); err != nil {
+		return false, err
+	}
+	if l.InstanceID == instanceID {
+		return true, nil
+	}
+	for _, pattern := range l.InstanceIDs {
+		// Patterns were validated, so Match cannot fail
+		if matched, _ := path.Match(pattern, instanceID); matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (l *License) matchInstance(instanceID string, policy *MatchPolicy) error {
+	if instanceID == "" {
+		return policy.matchIdentity(IdentityFieldInstance, "instance id", l.InstanceID, nil)
+	}
+	if len(l.InstanceIDs) == 0 {
+		return policy.matchIdentity(IdentityFieldInstance, "instance id", l.InstanceID, []string{instanceID})
+	}
+
+	matched, err := l.MatchesInstance(instanceID)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return fmt.Errorf("invalid instance id %s, expected one of %s", instanceID, strings.Join(slices.Concat([]string{l.InstanceID}, l.InstanceIDs), ", "))
+	}
+	return nil
+}
+
+// ValidateInstanceIDPatterns checks that instance IDs are not empty and are well formed path.Match patterns.
+func ValidateInstanceIDPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			return errors.New("empty instance id pattern")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid instance id pattern %q", pattern)
+		}
+	}
 	return nil
 }
 
@@ -75,12 +195,51 @@ func (l *License) IsExpiredAt(t time.Time) bool {
 	return t.UTC().After(expirationTime)
 }
 
+func (l *License) IsNotYetValidAt(t time.Time) bool {
+	notBefore, _ := l.GetNotBefore()
+	return t.UTC().Before(notBefore)
+}
+
 func (l *License) Renew(expirationTime time.Time) {
+	if l.OriginalIssueTime == "" {
+		l.OriginalIssueTime = l.CreationTime
+	}
 	l.CreationTime = time.Now().UTC().Format(time.RFC3339)
 	l.ExpirationTime = expirationTime.UTC().Format(time.RFC3339)
 	l.Version++
 }
 
+// Clone returns a copy of the license that can be modified without affecting the original.
+func (l *License) Clone() *License {
+	clone := *l
+	clone.InstanceIDs = slices.Clone(l.InstanceIDs)
+	clone.HostFingerprint = l.HostFingerprint.Clone()
+	clone.Features = slices.Clone(l.Features)
+	clone.Limits = maps.Clone(l.Limits)
+	clone.Metadata = maps.Clone(l.Metadata)
+	return &clone
+}
+
+// NextVersion returns a copy of the license issued at creationTime with the version incremented and
+// linked to the signature of the current version. The original issue time is preserved.
+func (l *License) NextVersion(creationTime time.Time, previousSignature []byte) *License {
+	next := l.Clone()
+	if next.OriginalIssueTime == "" {
+		next.OriginalIssueTime = l.CreationTime
+	}
+	next.CreationTime = creationTime.UTC().Format(time.RFC3339)
+	next.PreviousSignatureHash = SignatureHash(previousSignature)
+	next.Version++
+	return next
+}
+
+// Renewed returns the next version of the license with a new expiration time, leaving the receiver untouched.
+func (l *License) Renewed(creationTime, expirationTime time.Time, previousSignature []byte) *License {
+	next := l.NextVersion(creationTime, previousSignature)
+	next.ExpirationTime = expirationTime.UTC().Format(time.RFC3339)
+	return next
+}
+
 func (l *License) Bytes() ([]byte, error) {
 	return json.Marshal(l)
 }
@@ -93,3 +252,9 @@ func (l *License) String() string {
 func (l *License) FromString(s string) error {
 	return json.Unmarshal([]byte(s), l)
 }
+
+// SignatureHash returns the hex encoded SHA-256 digest of a license signature.
+func SignatureHash(signature []byte) string {
+	sum := sha256.Sum256(signature)
+	return hex.EncodeToString(sum[:])
+}
@@ -9,6 +9,8 @@ import (
 type LicenseEnvelope struct {
 	License   *License `json:"License"`
 	Signature []byte   `json:"Signature"`
+	// Timestamp is an RFC 3161 timestamp token over the signature, proving when the license was signed.
+	Timestamp []byte `json:"Timestamp,omitempty"`
 }
 
 func NewLicenseEnvelope(license *License, signature []byte) *LicenseEnvelope {
@@ -19,7 +21,7 @@ func NewLicenseEnvelope(license *License, signature []byte) *LicenseEnvelope {
 }
 
 func (le *LicenseEnvelope) IsValid() bool {
-	if le.License == nil || len(le.Signature) == 0 {
+	if le == nil || le.License == nil || len(le.Signature) == 0 {
 		return false
 	}
 	return true
@@ -55,6 +55,53 @@ func TestRenew(t *testing.T) {
 	assert.Equal(t, expirationTime.Format(time.RFC3339), license.ExpirationTime)
 }
 
+func TestRenewKeepsOriginalIssueTime(t *testing.T) {
+	t.Parallel()
+
+	license := License{
+		ID:             "1234",
+		CreationTime:   "2021-01-01T00:00:00Z",
+		ExpirationTime: "2022-01-01T00:00:00Z",
+	}
+	license.Renew(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	license.Renew(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, "2021-01-01T00:00:00Z", license.OriginalIssueTime)
+	assert.Equal(t, uint64(2), license.Version)
+}
+
+func TestRenewed(t *testing.T) {
+	t.Parallel()
+
+	license := &License{
+		ID:             "1234",
+		CreationTime:   "2021-01-01T00:00:00Z",
+		ExpirationTime: "2022-01-01T00:00:00Z",
+		Version:        1,
+	}
+	original := *license
+
+	creationTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	expirationTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	renewed := license.Renewed(creationTime, expirationTime, []byte("signature"))
+
+	assert.Equal(t, original, *license)
+	assert.Equal(t, "1234", renewed.ID)
+	assert.Equal(t, uint64(2), renewed.Version)
+	assert.Equal(t, "2021-12-01T00:00:00Z", renewed.CreationTime)
+	assert.Equal(t, "2023-01-01T00:00:00Z", renewed.ExpirationTime)
+	assert.Equal(t, "2021-01-01T00:00:00Z", renewed.OriginalIssueTime)
+	assert.Equal(t, SignatureHash([]byte("signature")), renewed.PreviousSignatureHash)
+
+	originalIssueTime, err := renewed.GetOriginalIssueTime()
+	require.NoError(t, err)
+	assert.Equal(t, "2021-01-01T00:00:00Z", originalIssueTime.Format(time.RFC3339))
+
+	originalIssueTime, err = license.GetOriginalIssueTime()
+	require.NoError(t, err)
+	assert.Equal(t, "2021-01-01T00:00:00Z", originalIssueTime.Format(time.RFC3339))
+}
+
 func TestGetCreationTime(t *testing.T) {
 	t.Parallel()
 
@@ -149,6 +196,101 @@ func TestIsValidWithInstanceProductPlanUniqueID(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestIsValidWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	license := License{
+		ID:                  "1234",
+		OrganizationID:      "org-id",
+		ProductPlanUniqueID: "SKU",
+		InstanceID:          "instance-id",
+		CreationTime:        "2021-01-01T00:00:00Z",
+		ExpirationTime:      "2022-01-01T00:00:00Z",
+	}
+
+	// Empty expected values are errors for required fields only
+	policy := &MatchPolicy{Required: []IdentityField{IdentityFieldInstance}}
+	assert.NoError(t, license.IsValidWithPolicy("", "", "instance-id", policy))
+	assert.ErrorContains(t, license.IsValidWithPolicy("org-id", "SKU", "", policy), "missing expected instance id")
+
+	strict := StrictMatchPolicy()
+	assert.NoError(t, license.IsValidWithPolicy("org-id", "SKU", "instance-id", strict))
+	assert.Error(t, license.IsValidWithPolicy("", "SKU", "instance-id", strict))
+	assert.Error(t, license.IsValidWithPolicy("org-id", "", "instance-id", strict))
+
+	// Allowed sets are accepted along with the expected value
+	allowed := &MatchPolicy{
+		Required:             []IdentityField{IdentityFieldProductPlan},
+		OrganizationIDs:      []string{"other-org", "org-id"},
+		ProductPlanUniqueIDs: []string{"SKU-basic", "SKU"},
+	}
+	assert.NoError(t, license.IsValidWithPolicy("", "", "", allowed))
+	assert.NoError(t, license.IsValidWithPolicy("other-org", "SKU-basic", "", allowed))
+	assert.Error(t, license.IsValidWithPolicy("", "", "", &MatchPolicy{OrganizationIDs: []string{"other-org"}}))
+	assert.Error(t, license.IsValidWithPolicy("", "", "", &MatchPolicy{ProductPlanUniqueIDs: []string{"SKU-basic"}}))
+
+	// A required field is satisfied by an allowed set alone
+	assert.NoError(t, license.IsValidWithPolicy("", "", "", &MatchPolicy{
+		Required:        []IdentityField{IdentityFieldOrganization},
+		OrganizationIDs: []string{"org-id"},
+	}))
+}
+
+func TestMatchesInstance(t *testing.T) {
+	t.Parallel()
+
+	license := License{
+		InstanceID:  "instance-primary",
+		InstanceIDs: []string{"instance-dr", "fleet-*", "node-[0-9]"},
+	}
+
+	for instanceID, expected := range map[string]bool{
+		"instance-primary": true,
+		"instance-dr":      true,
+		"fleet-a":          true,
+		"fleet-":           true,
+		"node-7":           true,
+		"node-10":          false,
+		"fleet/a":          false, // * does not match separators
+		"instance-dr-2":    false,
+		"INSTANCE-DR":      false,
+		"":                 false,
+	} {
+		matched, err := license.MatchesInstance(instanceID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, matched, instanceID)
+	}
+
+	// Malformed patterns fail whatever the instance, even one matching InstanceID
+	malformed := License{InstanceID: "instance-1", InstanceIDs: []string{"instance-[1"}}
+	_, err := malformed.MatchesInstance("instance-1")
+	assert.Error(t, err)
+	assert.Error(t, ValidateInstanceIDPatterns([]string{""}))
+	assert.NoError(t, ValidateInstanceIDPatterns([]string{"instance-?", "*"}))
+}
+
+func TestIsValidWithInstanceIDs(t *testing.T) {
+	t.Parallel()
+
+	license := License{
+		ID:             "1234",
+		InstanceIDs:    []string{"instance-1", "instance-dr-*"},
+		CreationTime:   "2021-01-01T00:00:00Z",
+		ExpirationTime: "2022-01-01T00:00:00Z",
+	}
+
+	assert.NoError(t, license.IsValid("", "", "instance-1"))
+	assert.NoError(t, license.IsValid("", "", "instance-dr-eu"))
+	assert.NoError(t, license.IsValid("", "", ""))
+	assert.Error(t, license.IsValid("", "", "instance-2"))
+	assert.Error(t, license.IsValidWithPolicy("", "", "", &MatchPolicy{Required: []IdentityField{IdentityFieldInstance}}))
+
+	// Licenses without InstanceIDs are signed as before
+	data, err := json.Marshal(License{ID: "1234", InstanceID: "instance-1"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ID":"1234","InstanceID":"instance-1"}`, string(data))
+}
+
 func TestIsExpired(t *testing.T) {
 	t.Parallel()
 
@@ -234,3 +376,75 @@ func TestLicenseSerialization(t *testing.T) {
 
 	assert.Equal(license, license2, "license should be the same after serialization")
 }
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	license := &License{
+		ID:              "1234",
+		Features:        []string{"sso"},
+		Limits:          map[string]int64{"seats": 10},
+		HostFingerprint: &HostFingerprint{Factors: map[string]string{"machine_id": "hash"}},
+	}
+	clone := license.Clone()
+	clone.Features[0] = "audit-log"
+	clone.Limits["seats"] = 20
+	clone.HostFingerprint.Factors["machine_id"] = "other"
+
+	assert.Equal(t, []string{"sso"}, license.Features)
+	assert.Equal(t, int64(10), license.Limits["seats"])
+	assert.True(t, license.HasFeature("sso"))
+	assert.False(t, license.HasFeature("audit-log"))
+	assert.Equal(t, "hash", license.HostFingerprint.Factors["machine_id"])
+}
+
+func TestHostFingerprintMatch(t *testing.T) {
+	t.Parallel()
+
+	fingerprint := &HostFingerprint{Factors: map[string]string{"machine_id": "a", "product_uuid": "b", "cpu": "c"}, MinMatches: 2}
+	require.NoError(t, fingerprint.Validate())
+	assert.Equal(t, []string{"cpu", "machine_id", "product_uuid"}, fingerprint.FactorNames())
+
+	require.NoError(t, fingerprint.Match(map[string]string{"machine_id": "a", "product_uuid": "b", "cpu": "c"}))
+	require.NoError(t, fingerprint.Match(map[string]string{"machine_id": "a", "cpu": "c"}))
+	err := fingerprint.Match(map[string]string{"machine_id": "a", "product_uuid": "x"})
+	require.EqualError(t, err, "host fingerprint matches 1 of 3 factors, 2 required")
+
+	// Every factor must match by default
+	fingerprint.MinMatches = 0
+	require.Error(t, fingerprint.Match(map[string]string{"machine_id": "a", "cpu": "c"}))
+
+	assert.Error(t, (&HostFingerprint{}).Validate())
+	assert.Error(t, (&HostFingerprint{Factors: map[string]string{"machine_id": ""}}).Validate())
+	assert.Error(t, (&HostFingerprint{Factors: map[string]string{"machine_id": "a"}, MinMatches: 2}).Validate())
+	assert.Error(t, (&HostFingerprint{Factors: map[string]string{"machine_id": "a"}, MinMatches: -1}).Validate())
+}
+
+func TestGetLineageID(t *testing.T) {
+	t.Parallel()
+
+	license := &License{ID: "1234"}
+	assert.Equal(t, "1234", license.GetLineageID())
+
+	license.LineageID = "5678"
+	assert.Equal(t, "5678", license.GetLineageID())
+}
+
+func TestIsNotYetValidAt(t *testing.T) {
+	t.Parallel()
+
+	license := License{
+		ID:             "1234",
+		CreationTime:   "2021-01-01T00:00:00Z",
+		ExpirationTime: "2022-01-01T00:00:00Z",
+	}
+	assert.False(t, license.IsNotYetValidAt(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	license.NotBefore = "2021-02-01T00:00:00Z"
+	assert.NoError(t, license.IsValid("", "", ""))
+	assert.True(t, license.IsNotYetValidAt(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, license.IsNotYetValidAt(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)))
+
+	license.NotBefore = "invalid"
+	assert.Error(t, license.IsValid("", "", ""))
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// IdentityField is a license field binding the license to a deployment.
+type IdentityField string
+
+const (
+	IdentityFieldOrganization IdentityField = "organization"
+	IdentityFieldProductPlan  IdentityField = "product_plan"
+	IdentityFieldInstance     IdentityField = "instance"
+)
+
+// MatchPolicy tightens how a license is matched against the expected organization, plan and instance.
+// Without a policy, the check of a field is skipped when its expected value is empty.
+type MatchPolicy struct {
+	// Required fields fail the validation when their expected value is empty, instead of skipping the check.
+	Required []IdentityField
+	// OrganizationIDs are accepted in addition to the expected organization ID.
+	OrganizationIDs []string
+	// ProductPlanUniqueIDs are accepted in addition to the expected product plan unique ID.
+	ProductPlanUniqueIDs []string
+}
+
+// StrictMatchPolicy requires the organization, plan and instance to be set.
+func StrictMatchPolicy() *MatchPolicy {
+	return &MatchPolicy{
+		Required: []IdentityField{IdentityFieldOrganization, IdentityFieldProductPlan, IdentityFieldInstance},
+	}
+}
+
+func (p *MatchPolicy) requires(field IdentityField) bool {
+	return p != nil && slices.Contains(p.Required, field)
+}
+
+func (p *MatchPolicy) organizationIDs(orgID string) []string {
+	if p == nil {
+		return expectedValues(orgID, nil)
+	}
+	return expectedValues(orgID, p.OrganizationIDs)
+}
+
+func (p *MatchPolicy) productPlanUniqueIDs(productPlanUniqueID string) []string {
+	if p == nil {
+		return expectedValues(productPlanUniqueID, nil)
+	}
+	return expectedValues(productPlanUniqueID, p.ProductPlanUniqueIDs)
+}
+
+// expectedValues returns the non-empty expected values.
+func expectedValues(expected string, allowed []string) []string {
+	values := make([]string, 0, len(allowed)+1)
+	for _, value := range slices.Concat([]string{expected}, allowed) {
+		if value != "" && !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// matchIdentity checks a license field against its expected values, and fails when there are none and the
+// policy requires the field.
+func (p *MatchPolicy) matchIdentity(field IdentityField, name, actual string, expected []string) error {
+	if len(expected) == 0 {
+		if p.requires(field) {
+			return fmt.Errorf("missing expected %s, required by the match policy", name)
+		}
+		return nil
+	}
+	if !slices.Contains(expected, actual) {
+		return fmt.Errorf("invalid %s %s, expected %s", name, strings.Join(expected, ", "), actual)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeAttestationType tags signed time attestations, so their signature can never be mistaken for a license signature.
+const TimeAttestationType = "TimeAttestation"
+
+// TimeAttestation is a statement of the current time, signed by the license issuer for validators
+// that have no trustworthy clock.
+type TimeAttestation struct {
+	Type      string `json:"Type"`
+	ID        string `json:"ID"`
+	IssueTime string `json:"IssueTime"`
+}
+
+func NewTimeAttestation(issueTime time.Time) *TimeAttestation {
+	return &TimeAttestation{
+		Type:      TimeAttestationType,
+		ID:        uuid.NewString(),
+		IssueTime: issueTime.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func (a *TimeAttestation) GetIssueTime() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, a.IssueTime)
+}
+
+func (a *TimeAttestation) Bytes() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+type TimeAttestationEnvelope struct {
+	Attestation *TimeAttestation `json:"Attestation"`
+	Signature   []byte           `json:"Signature"`
+}
+
+func NewTimeAttestationEnvelope(attestation *TimeAttestation, signature []byte) *TimeAttestationEnvelope {
+	return &TimeAttestationEnvelope{
+		Attestation: attestation,
+		Signature:   signature,
+	}
+}
+
+func (te *TimeAttestationEnvelope) IsValid() bool {
+	if te == nil || te.Attestation == nil || te.Attestation.Type != TimeAttestationType || len(te.Signature) == 0 {
+		return false
+	}
+	return true
+}
+
+func (te *TimeAttestationEnvelope) Bytes() ([]byte, error) {
+	return json.Marshal(te)
+}
+
+func (te *TimeAttestationEnvelope) String() string {
+	jsonBytes, _ := te.Bytes()
+	return string(jsonBytes)
+}
+
+func (te *TimeAttestationEnvelope) EncodeBase64() string {
+	jsonBytes, _ := te.Bytes()
+	return base64.StdEncoding.EncodeToString(jsonBytes)
+}
+
+func DecodeTimeAttestationEnvelopeFromBytes(data []byte) (*TimeAttestationEnvelope, error) {
+	te := &TimeAttestationEnvelope{}
+	err := json.Unmarshal(data, te)
+	if err != nil {
+		return nil, err
+	}
+	return te, nil
+}
+
+func DecodeTimeAttestationEnvelopeFromBase64(data string) (*TimeAttestationEnvelope, error) {
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeTimeAttestationEnvelopeFromBytes(decoded)
+}
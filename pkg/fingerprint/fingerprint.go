@@ -0,0 +1,256 @@
+// Package fingerprint derives a stable host identifier from Linux host identifiers, to bind node-locked
+// licenses to a host. Identifiers are never exposed: each one is hashed with HMAC-SHA256 under a salt.
+package fingerprint
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FactorMachineID is the systemd machine ID, from /etc/machine-id or /var/lib/dbus/machine-id.
+	FactorMachineID = "machine_id"
+	// FactorProductUUID is the DMI product UUID, from /sys/class/dmi/id/product_uuid. Reading it usually
+	// requires root.
+	FactorProductUUID = "product_uuid"
+	// FactorMACAddresses is the sorted MAC addresses of the physical network interfaces, from /sys/class/net.
+	FactorMACAddresses = "mac_addresses"
+	// FactorCPU is the CPU vendor and model, from /proc/cpuinfo.
+	FactorCPU = "cpu"
+)
+
+// DefaultFactors are the factors computed when none are given.
+var DefaultFactors = []string{FactorMachineID, FactorProductUUID, FactorMACAddresses, FactorCPU}
+
+// defaultSalt scopes hashes when no salt is set.
+var defaultSalt = []byte("omnistrate-licensing-sdk-go/fingerprint")
+
+// maxSourceSize caps how much is read from an identifier file.
+const maxSourceSize = 64 << 10
+
+// ErrNoFactors is returned when none of the requested factors could be read on the host.
+var ErrNoFactors = errors.New("no host identifiers available")
+
+// Source reads the raw value of a factor, relative to the root of the host filesystem. It returns an empty
+// value when the factor is not available on the host.
+type Source func(root string) (string, error)
+
+type Option func(*Fingerprinter)
+
+// WithSalt scopes the hashes, so fingerprints computed for different products cannot be correlated. The same
+// salt must be used when binding a license and when validating it.
+func WithSalt(salt []byte) Option {
+	return func(f *Fingerprinter) {
+		f.salt = salt
+	}
+}
+
+// WithRoot reads the identifiers below root instead of /, such as the host filesystem mounted in a container.
+func WithRoot(root string) Option {
+	return func(f *Fingerprinter) {
+		f.root = root
+	}
+}
+
+// WithSource adds a factor, or replaces how a default factor is read.
+func WithSource(factor string, source Source) Option {
+	return func(f *Fingerprinter) {
+		f.sources[factor] = source
+	}
+}
+
+// Fingerprinter computes host fingerprints. It implements validator.HostFingerprinter.
+type Fingerprinter struct {
+	root    string
+	salt    []byte
+	sources map[string]Source
+}
+
+func New(opts ...Option) *Fingerprinter {
+	f := &Fingerprinter{
+		root: "/",
+		salt: defaultSalt,
+		sources: map[string]Source{
+			FactorMachineID:    readMachineID,
+			FactorProductUUID:  readProductUUID,
+			FactorMACAddresses: readMACAddresses,
+			FactorCPU:          readCPU,
+		},
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Fingerprint returns the hashes of the factors, keyed by factor name, or of DefaultFactors when none are given.
+// Factors that are unknown or not available on the host are left out, and fail to match. It fails with
+// ErrNoFactors when no factor is available.
+func (f *Fingerprinter) Fingerprint(factors []string) (map[string]string, error) {
+	if len(factors) == 0 {
+		factors = DefaultFactors
+	}
+
+	hashes := make(map[string]string, len(factors))
+	for _, factor := range factors {
+		source, ok := f.sources[factor]
+		if !ok {
+			continue
+		}
+		// Identifiers that cannot be read, such as the DMI UUID without root, are not available
+		value, err := source(f.root)
+		if err != nil || value == "" {
+			continue
+		}
+		hashes[factor] = f.hash(factor, value)
+	}
+
+	if len(hashes) == 0 {
+		return nil, ErrNoFactors
+	}
+	return hashes, nil
+}
+
+// Binding computes the fingerprint of the host to bind a license to, requiring minMatches of the factors to
+// match during validation, or all of them when zero. It fails when a requested factor is not available.
+func (f *Fingerprinter) Binding(minMatches int, factors ...string) (*common.HostFingerprint, error) {
+	if len(factors) == 0 {
+		factors = DefaultFactors
+	}
+
+	hashes, err := f.Fingerprint(factors)
+	if err != nil {
+		return nil, err
+	}
+	for _, factor := range factors {
+		if _, ok := hashes[factor]; !ok {
+			return nil, fmt.Errorf("host identifier %s is not available", factor)
+		}
+	}
+
+	binding := &common.HostFingerprint{Factors: hashes, MinMatches: minMatches}
+	if err := binding.Validate(); err != nil {
+		return nil, err
+	}
+	return binding, nil
+}
+
+func (f *Fingerprinter) hash(factor, value string) string {
+	mac := hmac.New(sha256.New, f.salt)
+	mac.Write([]byte(factor))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func readMachineID(root string) (string, error) {
+	for _, name := range []string{"etc/machine-id", "var/lib/dbus/machine-id"} {
+		value, err := readValue(root, name)
+		if err != nil || value != "" {
+			return value, err
+		}
+	}
+	return "", nil
+}
+
+func readProductUUID(root string) (string, error) {
+	value, err := readValue(root, "sys/class/dmi/id/product_uuid")
+	if err != nil {
+		return "", err
+	}
+	// Placeholder UUIDs set by some firmware do not identify the host
+	if strings.Trim(value, "0-") == "" || strings.Trim(value, "f-") == "" {
+		return "", nil
+	}
+	return value, nil
+}
+
+func readMACAddresses(root string) (string, error) {
+	interfaces, err := os.ReadDir(filepath.Join(root, "sys/class/net"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var addresses []string
+	for _, entry := range interfaces {
+		// Only physical interfaces have a device, which leaves out loopback, bridges and virtual interfaces
+		if _, err := os.Stat(filepath.Join(root, "sys/class/net", entry.Name(), "device")); err != nil {
+			continue
+		}
+		address, err := readValue(root, filepath.Join("sys/class/net", entry.Name(), "address"))
+		if err != nil || address == "" || address == "00:00:00:00:00:00" {
+			continue
+		}
+		if !slices.Contains(addresses, address) {
+			addresses = append(addresses, address)
+		}
+	}
+	slices.Sort(addresses)
+	return strings.Join(addresses, ","), nil
+}
+
+func readCPU(root string) (string, error) {
+	data, err := readFile(root, "proc/cpuinfo")
+	if err != nil {
+		return "", err
+	}
+
+	// The first processor describes the CPU on x86 and ARM
+	fields := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" && len(fields) > 0 {
+			break
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if ok {
+			fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	var parts []string
+	for _, key := range []string{"vendor_id", "model name", "CPU implementer", "CPU architecture", "CPU part"} {
+		if value := fields[key]; value != "" {
+			parts = append(parts, key+"="+value)
+		}
+	}
+	return strings.Join(parts, ";"), nil
+}
+
+// readValue reads a single value file, normalized to lower case.
+func readValue(root, name string) (string, error) {
+	data, err := readFile(root, name)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(strings.TrimSpace(string(data))), nil
+}
+
+// readFile reads the beginning of a file, which has no content when missing.
+func readFile(root, name string) ([]byte, error) {
+	file, err := os.Open(filepath.Join(root, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(io.LimitReader(file, maxSourceSize))
+}
@@ -0,0 +1,111 @@
+package fingerprint
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeHostFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func newHost(t *testing.T) string {
+	root := t.TempDir()
+	writeHostFile(t, root, "etc/machine-id", "0123456789abcdef0123456789abcdef\n")
+	writeHostFile(t, root, "sys/class/dmi/id/product_uuid", "4C4C4544-0042-3510-8052-B4C04F4E4E32\n")
+	writeHostFile(t, root, "sys/class/net/eth0/address", "52:54:00:AB:CD:EF\n")
+	writeHostFile(t, root, "sys/class/net/eth0/device/uevent", "")
+	writeHostFile(t, root, "sys/class/net/lo/address", "00:00:00:00:00:00\n")
+	writeHostFile(t, root, "sys/class/net/docker0/address", "02:42:ac:11:00:01\n")
+	writeHostFile(t, root, "proc/cpuinfo", "processor\t: 0\nvendor_id\t: GenuineIntel\nmodel name\t: Intel(R) Xeon(R)\ncpu MHz\t\t: 2199.998\n\nprocessor\t: 1\nvendor_id\t: GenuineIntel\ncpu MHz\t\t: 2000.000\n")
+	return root
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	root := newHost(t)
+	hashes, err := New(WithRoot(root)).Fingerprint(nil)
+	require.NoError(t, err)
+	assert.Len(t, hashes, len(DefaultFactors))
+	for _, hash := range hashes {
+		assert.Len(t, hash, 64)
+	}
+
+	// Hashes are stable, and do not depend on values that change at runtime such as the CPU frequency or the
+	// virtual interfaces
+	writeHostFile(t, root, "proc/cpuinfo", "processor\t: 0\nvendor_id\t: GenuineIntel\nmodel name\t: Intel(R) Xeon(R)\ncpu MHz\t\t: 3000.000\n")
+	writeHostFile(t, root, "sys/class/net/veth1/address", "0a:58:0a:f4:00:01\n")
+	again, err := New(WithRoot(root)).Fingerprint(nil)
+	require.NoError(t, err)
+	assert.Equal(t, hashes, again)
+
+	// The salt scopes the hashes
+	salted, err := New(WithRoot(root), WithSalt([]byte("product"))).Fingerprint(nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, hashes[FactorMachineID], salted[FactorMachineID])
+
+	// Hashes do not reveal the identifiers, and the same value hashes differently for each factor
+	same, err := New(WithRoot(root),
+		WithSource(FactorMachineID, func(string) (string, error) { return "value", nil }),
+		WithSource(FactorCPU, func(string) (string, error) { return "value", nil })).Fingerprint([]string{FactorMachineID, FactorCPU})
+	require.NoError(t, err)
+	assert.NotEqual(t, same[FactorMachineID], same[FactorCPU])
+	assert.NotContains(t, same[FactorMachineID], "value")
+}
+
+func TestFingerprint_MissingFactors(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	_, err := New(WithRoot(root)).Fingerprint(nil)
+	require.ErrorIs(t, err, ErrNoFactors)
+
+	// Placeholder UUIDs and unknown factors are not available
+	writeHostFile(t, root, "var/lib/dbus/machine-id", "0123456789abcdef0123456789abcdef\n")
+	writeHostFile(t, root, "sys/class/dmi/id/product_uuid", "FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF\n")
+	hashes, err := New(WithRoot(root)).Fingerprint([]string{FactorMachineID, FactorProductUUID, "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{FactorMachineID}, slices.Collect(maps.Keys(hashes)))
+}
+
+func TestBinding(t *testing.T) {
+	t.Parallel()
+
+	root := newHost(t)
+	fingerprinter := New(WithRoot(root))
+	binding, err := fingerprinter.Binding(3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, binding.MinMatches)
+	assert.Len(t, binding.Factors, len(DefaultFactors))
+
+	// Replacing the network card keeps 3 of the 4 factors
+	require.NoError(t, os.RemoveAll(filepath.Join(root, "sys/class/net/eth0")))
+	writeHostFile(t, root, "sys/class/net/eth1/address", "52:54:00:12:34:56\n")
+	writeHostFile(t, root, "sys/class/net/eth1/device/uevent", "")
+	current, err := fingerprinter.Fingerprint(binding.FactorNames())
+	require.NoError(t, err)
+	require.NoError(t, binding.Match(current))
+
+	// Another host does not match
+	other := newHost(t)
+	writeHostFile(t, other, "etc/machine-id", "fedcba9876543210fedcba9876543210\n")
+	writeHostFile(t, other, "sys/class/dmi/id/product_uuid", "4C4C4544-0042-3510-8052-B4C04F4E4E33\n")
+	current, err = New(WithRoot(other)).Fingerprint(binding.FactorNames())
+	require.NoError(t, err)
+	require.Error(t, binding.Match(current))
+
+	_, err = fingerprinter.Binding(1, FactorMachineID, "unknown")
+	require.Error(t, err)
+	_, err = fingerprinter.Binding(3, FactorMachineID)
+	require.Error(t, err)
+}
@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+)
+
+// LicenseAmendment describes the changes applied to a license by AmendLicense.
+// Nil fields are left unchanged. Non-nil InstanceIDs, Features and Limits replace the existing values, so an
+// empty slice or map clears them. A new ExpirationTime is checked against the renewal policy.
+type LicenseAmendment struct {
+	ProductPlanUniqueID *string          `json:"productPlanUniqueID,omitempty"`
+	InstanceID          *string          `json:"instanceID,omitempty"`
+	InstanceIDs         []string         `json:"instanceIDs,omitempty"`
+	Description         *string          `json:"description,omitempty"`
+	ExpirationTime      *time.Time       `json:"expirationTime,omitempty"`
+	Features            []string         `json:"features,omitempty"`
+	Limits              map[string]int64 `json:"limits,omitempty"`
+}
+
+func (a LicenseAmendment) IsEmpty() bool {
+	return a.ProductPlanUniqueID == nil &&
+		a.InstanceID == nil &&
+		a.InstanceIDs == nil &&
+		a.Description == nil &&
+		a.ExpirationTime == nil &&
+		a.Features == nil &&
+		a.Limits == nil
+}
+
+func (a LicenseAmendment) apply(license *common.License) {
+	if a.ProductPlanUniqueID != nil {
+		license.ProductPlanUniqueID = *a.ProductPlanUniqueID
+	}
+	if a.InstanceID != nil {
+		license.InstanceID = *a.InstanceID
+	}
+	if a.InstanceIDs != nil {
+		license.InstanceIDs = slices.Clone(a.InstanceIDs)
+	}
+	if a.Description != nil {
+		license.Description = *a.Description
+	}
+	if a.ExpirationTime != nil {
+		license.ExpirationTime = a.ExpirationTime.UTC().Format(time.RFC3339)
+	}
+	if a.Features != nil {
+		license.Features = slices.Clone(a.Features)
+	}
+	if a.Limits != nil {
+		license.Limits = maps.Clone(a.Limits)
+	}
+}
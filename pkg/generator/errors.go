@@ -0,0 +1,24 @@
+package generator
+
+import "github.com/pkg/errors"
+
+var (
+	ErrLicenseNotFound        = errors.New("license not found")
+	ErrEnvelopeRequired       = errors.New("envelope is required")
+	ErrEnvelopeInvalid        = errors.New("envelope is invalid")
+	ErrLicenseRequestRequired = errors.New("license request is required")
+	ErrInvalidLicenseRequest  = errors.New("invalid license request")
+	ErrEmptyAmendment         = errors.New("amendment has no changes")
+	ErrRenewalRejected        = errors.New("renewal rejected by policy")
+	ErrIssuanceStoreRequired  = errors.New("issuance store is required to revoke a license")
+
+	// ErrEnvelopeSignatureInvalid is returned when a license to renew or amend was not signed by the generator.
+	ErrEnvelopeSignatureInvalid = errors.New("envelope signature is invalid")
+	// ErrLicenseRevoked is returned when renewing or amending a license the issuance store records as revoked.
+	ErrLicenseRevoked = errors.New("license is revoked")
+	// ErrLicenseSuperseded is returned when renewing or amending a license the issuance store records as
+	// superseded by an amendment or a later version.
+	ErrLicenseSuperseded = errors.New("license is superseded")
+	// ErrInvalidAmendment is returned when an amendment sets invalid values, such as malformed instance ID patterns.
+	ErrInvalidAmendment = errors.New("invalid license amendment")
+)
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const maxIssuanceRecordSize = 1 << 20
+
+// FileIssuanceStore is an IssuanceStore persisted as an append-only JSON lines file.
+// Every recorded event is appended to the file and the latest record per license is kept in memory.
+type FileIssuanceStore struct {
+	*MemoryIssuanceStore
+	path string
+}
+
+func NewFileIssuanceStore(path string) (*FileIssuanceStore, error) {
+	if path == "" {
+		return nil, errors.New("issuance store path is required")
+	}
+
+	store := &FileIssuanceStore{
+		MemoryIssuanceStore: NewMemoryIssuanceStore(),
+		path:                path,
+	}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *FileIssuanceStore) load() error {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxIssuanceRecordSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record IssuanceRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return errors.Wrapf(err, "invalid issuance record at %s:%d", s.path, line)
+		}
+		s.apply(record)
+	}
+	return scanner.Err()
+}
+
+func (s *FileIssuanceStore) Record(record IssuanceRecord) error {
+	if record.LicenseID == "" {
+		return errors.New("license id is required")
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	s.apply(record)
+	return nil
+}
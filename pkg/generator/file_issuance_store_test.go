@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileIssuanceStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ledger", "issuance.jsonl")
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store, err := NewFileIssuanceStore(path)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Record(IssuanceRecord{LicenseID: "l1", OrganizationID: "org-1", Event: IssuanceEventIssued, CreationTime: base, ExpirationTime: base.AddDate(0, 1, 0)}))
+	require.NoError(t, store.Record(IssuanceRecord{LicenseID: "l2", LineageID: "l1", SupersededLicenseID: "l1", OrganizationID: "org-1", Event: IssuanceEventAmended, CreationTime: base.Add(time.Hour), ExpirationTime: base.AddDate(0, 1, 0)}))
+	require.NoError(t, store.Record(IssuanceRecord{LicenseID: "l2", LineageID: "l1", SupersededLicenseID: "l1", OrganizationID: "org-1", Event: IssuanceEventRenewed, Version: 3, CreationTime: base.Add(2 * time.Hour), ExpirationTime: base.AddDate(0, 2, 0)}))
+	require.NoError(t, store.Record(IssuanceRecord{LicenseID: "l3", OrganizationID: "org-2", Event: IssuanceEventRevoked, RevokedAt: base, RevocationReason: "churned", CreationTime: base, ExpirationTime: base.AddDate(0, 1, 0)}))
+	require.NoError(t, store.Record(IssuanceRecord{LicenseID: "l3", OrganizationID: "org-2", Event: IssuanceEventRenewed, Version: 2, CreationTime: base, ExpirationTime: base.AddDate(0, 2, 0)}))
+
+	// Reopen the store from disk
+	reopened, err := NewFileIssuanceStore(path)
+	require.NoError(t, err)
+
+	record, err := reopened.Get("l2")
+	require.NoError(t, err)
+	assert.Equal(t, IssuanceEventRenewed, record.Event)
+	assert.Equal(t, uint64(3), record.Version)
+	assert.Equal(t, base.AddDate(0, 2, 0), record.ExpirationTime)
+
+	record, err = reopened.Get("l1")
+	require.NoError(t, err)
+	assert.Equal(t, "l2", record.SupersededBy)
+
+	// The revocation survives the later event when replayed from disk
+	record, err = reopened.Get("l3")
+	require.NoError(t, err)
+	assert.True(t, record.IsRevoked())
+	assert.Equal(t, "churned", record.RevocationReason)
+
+	records, err := reopened.Query(IssuanceQuery{OrganizationID: "org-1"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "l2", records[0].LicenseID)
+
+	// Unset times are left out of the ledger
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	first, _, _ := strings.Cut(string(data), "\n")
+	assert.NotContains(t, first, "revokedAt")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestFileIssuanceStore_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFileIssuanceStore("")
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "issuance.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{not json}\n"), 0o600))
+	_, err = NewFileIssuanceStore(path)
+	assert.Error(t, err)
+}
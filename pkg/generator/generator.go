@@ -1,14 +1,17 @@
 package generator
 
 import (
+	"context"
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/certificate"
 	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/pkg/errors"
 )
 
 type GeneratorInterface interface {
@@ -19,27 +22,101 @@ type GeneratorInterface interface {
 	GetPublicCertificateBase64() string
 }
 
+// ContextGenerator extends GeneratorInterface with the context variants of the generations and the newer
+// methods. The generators returned by the constructors implement it.
+type ContextGenerator interface {
+	GeneratorInterface
+	GenerateLicenseFromRequest(request *LicenseRequest) (envelope *common.LicenseEnvelope, err error)
+	GenerateLicenseEncoded(request *LicenseRequest) (string, error)
+	AmendLicense(envelope *common.LicenseEnvelope, changes LicenseAmendment) (newEnvelope *common.LicenseEnvelope, err error)
+	AmendLicenseBase64(envelopeBase64 string, changes LicenseAmendment) (string, error)
+	RevokeLicense(licenseID, reason string) error
+	IssueTimeAttestation() (*common.TimeAttestationEnvelope, error)
+	IssueTimeAttestationBase64() (string, error)
+
+	// Context variants stop before signing or recording once the context is done, and pass it to the
+	// timestamp authority.
+	GenerateLicenseContext(ctx context.Context, orgId, productPlanUniqueID, instanceId, subscriptionId, description string, expirationDate time.Time) (envelope *common.LicenseEnvelope, err error)
+	GenerateLicenseBase64Context(ctx context.Context, orgId, productPlanUniqueID, instanceId, subscriptionId, description string, expirationDate time.Time) (string, error)
+	GenerateLicenseFromRequestContext(ctx context.Context, request *LicenseRequest) (envelope *common.LicenseEnvelope, err error)
+	GenerateLicenseEncodedContext(ctx context.Context, request *LicenseRequest) (string, error)
+	RenewLicenseContext(ctx context.Context, envelope *common.LicenseEnvelope, expirationDate time.Time) (newEnvelope *common.LicenseEnvelope, err error)
+	RenewLicenseBase64Context(ctx context.Context, envelopeBase64 string, expirationDate time.Time) (string, error)
+	AmendLicenseContext(ctx context.Context, envelope *common.LicenseEnvelope, changes LicenseAmendment) (newEnvelope *common.LicenseEnvelope, err error)
+	AmendLicenseBase64Context(ctx context.Context, envelopeBase64 string, changes LicenseAmendment) (string, error)
+	RevokeLicenseContext(ctx context.Context, licenseID, reason string) error
+	IssueTimeAttestationContext(ctx context.Context) (*common.TimeAttestationEnvelope, error)
+	IssueTimeAttestationBase64Context(ctx context.Context) (string, error)
+}
+
 type Manager struct {
-	key     *rsa.PrivateKey
-	certPEM []byte
+	key             *rsa.PrivateKey
+	certPEM         []byte
+	renewalPolicy   RenewalPolicy
+	store           IssuanceStore
+	tsa             TimestampAuthority
+	instrumentation common.Instrumentation
+
+	// locks makes the check that a license is current and the record of its successor atomic
+	locks licenseLocks
+}
+
+type GeneratorOption func(*Manager)
+
+func WithRenewalPolicy(policy RenewalPolicy) GeneratorOption {
+	return func(m *Manager) {
+		m.renewalPolicy = policy
+	}
+}
+
+// WithIssuanceStore records every issued, renewed, amended and revoked license in the store.
+// A Manager serializes the renewals and amendments of each license, so that only one successor is recorded.
+// Managers sharing a store in different processes are not serialized with each other.
+func WithIssuanceStore(store IssuanceStore) GeneratorOption {
+	return func(m *Manager) {
+		m.store = store
+	}
+}
+
+// TimestampAuthority obtains an RFC 3161 timestamp token over data. The timestamp package provides an implementation.
+type TimestampAuthority interface {
+	Timestamp(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// WithTimestampAuthority embeds a timestamp token over the signature of every license, proving when it was signed.
+func WithTimestampAuthority(tsa TimestampAuthority) GeneratorOption {
+	return func(m *Manager) {
+		m.tsa = tsa
+	}
 }
 
-func NewGenerator(key *rsa.PrivateKey, certPEM []byte) GeneratorInterface {
-	return &Manager{
+// WithInstrumentation reports license generations and renewals to the instrumentation.
+func WithInstrumentation(instrumentation common.Instrumentation) GeneratorOption {
+	return func(m *Manager) {
+		m.instrumentation = instrumentation
+	}
+}
+
+func NewGenerator(key *rsa.PrivateKey, certPEM []byte, opts ...GeneratorOption) GeneratorInterface {
+	m := &Manager{
 		key:     key,
 		certPEM: certPEM,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
-func NewGeneratorFromBytes(keyPEM []byte, certPEM []byte) (GeneratorInterface, error) {
+func NewGeneratorFromBytes(keyPEM []byte, certPEM []byte, opts ...GeneratorOption) (GeneratorInterface, error) {
 	key, err := certificate.LoadPrivateKeyFromBytes(keyPEM)
 	if err != nil {
 		return nil, err
 	}
-	return NewGenerator(key, certPEM), nil
+	return NewGenerator(key, certPEM, opts...), nil
 }
 
-func NewGeneratorFromFiles(keyPath string, certPath string) (GeneratorInterface, error) {
+func NewGeneratorFromFiles(keyPath string, certPath string, opts ...GeneratorOption) (GeneratorInterface, error) {
 	key, err := certificate.LoadPrivateKey(keyPath)
 	if err != nil {
 		return nil, err
@@ -48,19 +125,19 @@ func NewGeneratorFromFiles(keyPath string, certPath string) (GeneratorInterface,
 	if err != nil {
 		return nil, err
 	}
-	return NewGenerator(key, certPEM), nil
+	return NewGenerator(key, certPEM, opts...), nil
 }
 
-func NewGeneratorFromConfig(config *GeneratorConfig) (GeneratorInterface, error) {
+func NewGeneratorFromConfig(config *GeneratorConfig, opts ...GeneratorOption) (GeneratorInterface, error) {
 	if config == nil {
 		config = NewGeneratorConfigFromEnv()
 	}
-	return NewGeneratorFromFiles(config.KeyPath, config.CertPath)
+	return NewGeneratorFromFiles(config.KeyPath, config.CertPath, opts...)
 }
 
-func NewGeneratorFromEnv() (GeneratorInterface, error) {
+func NewGeneratorFromEnv(opts ...GeneratorOption) (GeneratorInterface, error) {
 	config := NewGeneratorConfigFromEnv()
-	return NewGeneratorFromConfig(config)
+	return NewGeneratorFromConfig(config, opts...)
 }
 
 func (m *Manager) GetPublicCertificateBase64() string {
@@ -78,26 +155,29 @@ func (m *Manager) GenerateLicense(
 	envelope *common.LicenseEnvelope,
 	err error,
 ) {
-	if m.key == nil {
-		return nil, fmt.Errorf("licenseKey is required to generate a license")
-	}
-
-	now := time.Now().UTC()
-	license := common.NewLicense(orgId, productPlanUniqueID, instanceId, subscriptionId, description, now, expirationDate)
-
-	// Sign with private key
-	licenseBytes, err := license.Bytes()
-	if err != nil {
-		return nil, err
-	}
-	signature, err := certificate.Sign(m.key, licenseBytes)
-	if err != nil {
-		return nil, err
-	}
+	return m.GenerateLicenseContext(context.Background(), orgId, productPlanUniqueID, instanceId, subscriptionId, description, expirationDate)
+}
 
-	// Create envelope
-	envelope = common.NewLicenseEnvelope(license, signature)
-	return
+func (m *Manager) GenerateLicenseContext(
+	ctx context.Context,
+	orgId string,
+	productPlanUniqueID string,
+	instanceId string,
+	subscriptionId string,
+	description string,
+	expirationDate time.Time,
+) (
+	envelope *common.LicenseEnvelope,
+	err error,
+) {
+	return m.GenerateLicenseFromRequestContext(ctx, NewLicenseRequest(
+		orgId,
+		productPlanUniqueID,
+		expirationDate,
+		WithInstanceID(instanceId),
+		WithSubscriptionID(subscriptionId),
+		WithDescription(description),
+	))
 }
 
 func (m *Manager) GenerateLicenseBase64(
@@ -111,12 +191,78 @@ func (m *Manager) GenerateLicenseBase64(
 	string,
 	error,
 ) {
-	envelope, err := m.GenerateLicense(orgId, productPlanUniqueID, instanceId, subscriptionId, description, expirationDate)
+	return m.GenerateLicenseBase64Context(context.Background(), orgId, productPlanUniqueID, instanceId, subscriptionId, description, expirationDate)
+}
+
+func (m *Manager) GenerateLicenseBase64Context(
+	ctx context.Context,
+	orgId string,
+	productPlanUniqueID string,
+	instanceId string,
+	subscriptionId string,
+	description string,
+	expirationDate time.Time,
+) (
+	string,
+	error,
+) {
+	return m.GenerateLicenseEncodedContext(ctx, NewLicenseRequest(
+		orgId,
+		productPlanUniqueID,
+		expirationDate,
+		WithInstanceID(instanceId),
+		WithSubscriptionID(subscriptionId),
+		WithDescription(description),
+		WithOutputFormat(OutputFormatBase64),
+	))
+}
+
+func (m *Manager) GenerateLicenseFromRequest(request *LicenseRequest) (envelope *common.LicenseEnvelope, err error) {
+	return m.GenerateLicenseFromRequestContext(context.Background(), request)
+}
+
+func (m *Manager) GenerateLicenseFromRequestContext(ctx context.Context, request *LicenseRequest) (envelope *common.LicenseEnvelope, err error) {
+	ctx, end := common.StartOperation(ctx, m.instrumentation, common.OperationGenerateLicense)
+	defer func() {
+		info := operationInfo(envelope)
+		if envelope == nil && request != nil {
+			info.OrganizationID = request.OrganizationID
+			info.ProductPlanUniqueID = request.ProductPlanUniqueID
+		}
+		end(info, err)
+	}()
+
+	if m.key == nil {
+		return nil, fmt.Errorf("licenseKey is required to generate a license")
+	}
+
+	if request == nil {
+		return nil, ErrLicenseRequestRequired
+	}
+
+	if err = request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLicenseRequest, err)
+	}
+
+	license := request.newLicense(time.Now().UTC())
+	if license.ID == "" {
+		return nil, fmt.Errorf("%w: id strategy returned an empty id", ErrInvalidLicenseRequest)
+	}
+
+	return m.issue(ctx, license, IssuanceEventIssued)
+}
+
+func (m *Manager) GenerateLicenseEncoded(request *LicenseRequest) (string, error) {
+	return m.GenerateLicenseEncodedContext(context.Background(), request)
+}
+
+func (m *Manager) GenerateLicenseEncodedContext(ctx context.Context, request *LicenseRequest) (string, error) {
+	envelope, err := m.GenerateLicenseFromRequestContext(ctx, request)
 	if err != nil {
 		return "", err
 	}
 
-	return envelope.EncodeBase64(), nil
+	return request.OutputFormat.Encode(envelope)
 }
 
 func (m *Manager) RenewLicense(
@@ -126,34 +272,65 @@ func (m *Manager) RenewLicense(
 	newEnvelope *common.LicenseEnvelope,
 	err error,
 ) {
+	return m.RenewLicenseContext(context.Background(), envelope, expirationDate)
+}
+
+func (m *Manager) RenewLicenseContext(
+	ctx context.Context,
+	envelope *common.LicenseEnvelope,
+	expirationDate time.Time,
+) (
+	newEnvelope *common.LicenseEnvelope,
+	err error,
+) {
+	ctx, end := common.StartOperation(ctx, m.instrumentation, common.OperationRenewLicense)
+	defer func() {
+		info := operationInfo(envelope)
+		if newEnvelope != nil {
+			info = operationInfo(newEnvelope)
+		}
+		end(info, err)
+	}()
+
+	if m.key == nil {
+		return nil, fmt.Errorf("licenseKey is required to renew a license")
+	}
+
 	if envelope == nil {
-		return nil, fmt.Errorf("envelope is required")
+		return nil, ErrEnvelopeRequired
 	}
 
 	if !envelope.IsValid() {
-		return nil, fmt.Errorf("envelope is invalid")
+		return nil, ErrEnvelopeInvalid
 	}
 
-	// Extract the license
-	license := envelope.License
-	license.Renew(expirationDate)
-
-	// Sign with private key
-	licenseBytes, err := license.Bytes()
-	if err != nil {
+	if err = m.verify(envelope); err != nil {
 		return nil, err
 	}
-	signature, err := certificate.Sign(m.key, licenseBytes)
-	if err != nil {
+
+	unlock := m.locks.lock(envelope.License.ID)
+	defer unlock()
+
+	if err = m.checkCurrent(envelope.License); err != nil {
 		return nil, err
 	}
 
-	// Create envelope
-	newEnvelope = common.NewLicenseEnvelope(license, signature)
-	return
+	now := time.Now().UTC()
+	if err = m.renewalPolicy.Check(envelope.License, expirationDate, now); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRenewalRejected, err)
+	}
+
+	// Build the next version of the license, leaving the original envelope untouched
+	license := envelope.License.Renewed(now, expirationDate, envelope.Signature)
+
+	return m.issue(ctx, license, IssuanceEventRenewed)
 }
 
 func (m *Manager) RenewLicenseBase64(envelopeBase64 string, expirationDate time.Time) (string, error) {
+	return m.RenewLicenseBase64Context(context.Background(), envelopeBase64, expirationDate)
+}
+
+func (m *Manager) RenewLicenseBase64Context(ctx context.Context, envelopeBase64 string, expirationDate time.Time) (string, error) {
 	// Decode the license envelope
 	envelope, err := common.DecodeLicenseEnvelopeFromBase64(envelopeBase64)
 	if err != nil {
@@ -161,10 +338,232 @@ func (m *Manager) RenewLicenseBase64(envelopeBase64 string, expirationDate time.
 	}
 
 	// Renew the license
-	newEnvelope, err := m.RenewLicense(envelope, expirationDate)
+	newEnvelope, err := m.RenewLicenseContext(ctx, envelope, expirationDate)
 	if err != nil {
 		return "", err
 	}
 
 	return newEnvelope.EncodeBase64(), nil
 }
+
+func (m *Manager) AmendLicense(
+	envelope *common.LicenseEnvelope,
+	changes LicenseAmendment,
+) (
+	newEnvelope *common.LicenseEnvelope,
+	err error,
+) {
+	return m.AmendLicenseContext(context.Background(), envelope, changes)
+}
+
+func (m *Manager) AmendLicenseContext(
+	ctx context.Context,
+	envelope *common.LicenseEnvelope,
+	changes LicenseAmendment,
+) (
+	newEnvelope *common.LicenseEnvelope,
+	err error,
+) {
+	if m.key == nil {
+		return nil, fmt.Errorf("licenseKey is required to amend a license")
+	}
+
+	if envelope == nil {
+		return nil, ErrEnvelopeRequired
+	}
+
+	if !envelope.IsValid() {
+		return nil, ErrEnvelopeInvalid
+	}
+
+	if changes.IsEmpty() {
+		return nil, ErrEmptyAmendment
+	}
+
+	if err := common.ValidateInstanceIDPatterns(changes.InstanceIDs); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAmendment, err)
+	}
+
+	if err := m.verify(envelope); err != nil {
+		return nil, err
+	}
+
+	unlock := m.locks.lock(envelope.License.ID)
+	defer unlock()
+
+	if err := m.checkCurrent(envelope.License); err != nil {
+		return nil, err
+	}
+
+	// Changing the expiration time is bound by the renewal policy, like a renewal
+	previous := envelope.License
+	now := time.Now().UTC()
+	if changes.ExpirationTime != nil {
+		if err = m.renewalPolicy.Check(previous, *changes.ExpirationTime, now); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRenewalRejected, err)
+		}
+	}
+
+	// The amended license gets a new ID but stays in the lineage of the license it supersedes
+	license := previous.NextVersion(now, envelope.Signature)
+	license.ID = uuid.NewString()
+	license.LineageID = previous.GetLineageID()
+	license.SupersededLicenseID = previous.ID
+	changes.apply(license)
+
+	return m.issue(ctx, license, IssuanceEventAmended)
+}
+
+func (m *Manager) AmendLicenseBase64(envelopeBase64 string, changes LicenseAmendment) (string, error) {
+	return m.AmendLicenseBase64Context(context.Background(), envelopeBase64, changes)
+}
+
+func (m *Manager) AmendLicenseBase64Context(ctx context.Context, envelopeBase64 string, changes LicenseAmendment) (string, error) {
+	// Decode the license envelope
+	envelope, err := common.DecodeLicenseEnvelopeFromBase64(envelopeBase64)
+	if err != nil {
+		return "", err
+	}
+
+	// Amend the license
+	newEnvelope, err := m.AmendLicenseContext(ctx, envelope, changes)
+	if err != nil {
+		return "", err
+	}
+
+	return newEnvelope.EncodeBase64(), nil
+}
+
+func (m *Manager) RevokeLicense(licenseID, reason string) error {
+	return m.RevokeLicenseContext(context.Background(), licenseID, reason)
+}
+
+func (m *Manager) RevokeLicenseContext(ctx context.Context, licenseID, reason string) error {
+	if m.store == nil {
+		return ErrIssuanceStoreRequired
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	unlock := m.locks.lock(licenseID)
+	defer unlock()
+
+	record, err := m.store.Get(licenseID)
+	if err != nil {
+		return err
+	}
+	if record.IsRevoked() {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	record.Event = IssuanceEventRevoked
+	record.RecordedAt = now
+	record.RevokedAt = now
+	record.RevocationReason = reason
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	return errors.Wrap(m.store.Record(*record), "failed to record license revocation")
+}
+
+// verify checks that the envelope was signed with the key of the generator, so that only licenses it issued
+// are renewed or amended.
+func (m *Manager) verify(envelope *common.LicenseEnvelope) error {
+	cert, err := certificate.LoadCertificateFromBytes(m.certPEM)
+	if err != nil {
+		return errors.Wrap(err, "failed to load signing certificate")
+	}
+	licenseBytes, err := envelope.License.Bytes()
+	if err != nil {
+		return err
+	}
+	if err = certificate.VerifySignature(cert, envelope.Signature, licenseBytes); err != nil {
+		return fmt.Errorf("%w: %w", ErrEnvelopeSignatureInvalid, err)
+	}
+	return nil
+}
+
+// checkCurrent refuses to build on a license that the issuance store records as revoked or superseded.
+// Licenses missing from the store, such as licenses issued before it was configured, are accepted.
+func (m *Manager) checkCurrent(license *common.License) error {
+	if m.store == nil {
+		return nil
+	}
+
+	record, err := m.store.Get(license.ID)
+	if errors.Is(err, ErrLicenseNotFound) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to read issuance record")
+	}
+
+	switch {
+	case record.IsRevoked():
+		return fmt.Errorf("%w: %s", ErrLicenseRevoked, license.ID)
+	case record.IsSuperseded():
+		return fmt.Errorf("%w: %s by %s", ErrLicenseSuperseded, license.ID, record.SupersededBy)
+	case record.Version > license.Version:
+		return fmt.Errorf("%w: %s by version %d", ErrLicenseSuperseded, license.ID, record.Version)
+	}
+	return nil
+}
+
+func (m *Manager) issue(ctx context.Context, license *common.License, event IssuanceEvent) (*common.LicenseEnvelope, error) {
+	envelope, err := m.sign(ctx, license)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.store != nil {
+		// A license signed after the context is done is dropped rather than recorded
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		record, err := NewIssuanceRecord(envelope, event, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if err = m.store.Record(*record); err != nil {
+			return nil, errors.Wrap(err, "failed to record license issuance")
+		}
+	}
+
+	return envelope, nil
+}
+
+func (m *Manager) sign(ctx context.Context, license *common.License) (*common.LicenseEnvelope, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Sign with private key
+	licenseBytes, err := license.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := certificate.Sign(m.key, licenseBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create envelope
+	envelope := common.NewLicenseEnvelope(license, signature)
+	if m.tsa != nil {
+		envelope.Timestamp, err = m.tsa.Timestamp(ctx, signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to timestamp license signature")
+		}
+	}
+	return envelope, nil
+}
+
+// operationInfo describes the license of the envelope for instrumentation, which may be nil or invalid.
+func operationInfo(envelope *common.LicenseEnvelope) common.OperationInfo {
+	if !envelope.IsValid() {
+		return common.OperationInfo{}
+	}
+	return common.NewOperationInfo(envelope.License)
+}
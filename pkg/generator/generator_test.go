@@ -1,8 +1,13 @@
 package generator
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	_ "embed"
 	"encoding/base64"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -70,6 +75,122 @@ func TestGenerator_RenewLicense(t *testing.T) {
 	assert.Greater(t, newExpirationTime.Unix(), oldExpirationTime.Unix())
 }
 
+func TestGenerator_RenewLicenseDoesNotMutate(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+
+	envelope, err := manager.GenerateLicenseFromRequest(NewLicenseRequest("orgId", "SKU", time.Now().UTC().Add(48*time.Hour),
+		WithIssueTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))))
+	require.NoError(t, err)
+	original := *envelope.License
+
+	newEnvelope, err := manager.RenewLicense(envelope, time.Now().UTC().Add(72*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, original, *envelope.License)
+	assert.NotSame(t, envelope.License, newEnvelope.License)
+	assert.Equal(t, "2025-01-01T00:00:00Z", newEnvelope.License.OriginalIssueTime)
+	assert.Equal(t, original.Version+1, newEnvelope.License.Version)
+	assert.Equal(t, common.SignatureHash(envelope.Signature), newEnvelope.License.PreviousSignatureHash)
+
+	renewedAgain, err := manager.RenewLicense(newEnvelope, time.Now().UTC().Add(96*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01T00:00:00Z", renewedAgain.License.OriginalIssueTime)
+	assert.Equal(t, original.Version+2, renewedAgain.License.Version)
+	assert.Equal(t, common.SignatureHash(newEnvelope.Signature), renewedAgain.License.PreviousSignatureHash)
+}
+
+func TestGenerator_RenewAndAmendVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+	expiration := time.Now().UTC().Add(48 * time.Hour)
+	plan := "ENTERPRISE"
+
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", expiration)
+	require.NoError(t, err)
+	tampered := &common.LicenseEnvelope{License: envelope.License.Clone(), Signature: envelope.Signature}
+	tampered.License.OrganizationID = "victim"
+
+	forged := common.NewLicenseEnvelope(envelope.License.Clone(), []byte("junk"))
+
+	// A license signed with another key under the same certificate
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	foreign, err := NewGenerator(otherKey, certPEM).GenerateLicense("victim", "SKU", "", "", "", expiration)
+	require.NoError(t, err)
+
+	for _, envelope := range []*common.LicenseEnvelope{tampered, forged, foreign} {
+		_, err = manager.RenewLicense(envelope, expiration.Add(time.Hour))
+		require.ErrorIs(t, err, ErrEnvelopeSignatureInvalid)
+		_, err = manager.AmendLicense(envelope, LicenseAmendment{ProductPlanUniqueID: &plan})
+		require.ErrorIs(t, err, ErrEnvelopeSignatureInvalid)
+	}
+}
+
+func TestGenerator_RenewLicenseWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	manager, err := NewGeneratorFromBytes(keyPEM, certPEM, WithRenewalPolicy(RenewalPolicy{MaxExtension: 24 * time.Hour}))
+	require.NoError(t, err)
+
+	expiration := time.Now().UTC().Add(48 * time.Hour)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", expiration)
+	require.NoError(t, err)
+
+	_, err = manager.RenewLicense(envelope, expiration.Add(12*time.Hour))
+	assert.NoError(t, err)
+
+	_, err = manager.RenewLicense(envelope, expiration.Add(36*time.Hour))
+	assert.Error(t, err)
+
+	_, err = manager.RenewLicense(envelope, expiration.Add(-time.Hour))
+	assert.Error(t, err)
+}
+
+func TestGenerator_AmendLicenseWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM, WithRenewalPolicy(RenewalPolicy{MaxExtension: 24 * time.Hour, MaxLifetime: 90 * time.Hour}))
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+
+	expiration := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Second)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", expiration)
+	require.NoError(t, err)
+	amend := func(envelope *common.LicenseEnvelope, expirationTime time.Time) (*common.LicenseEnvelope, error) {
+		return manager.AmendLicense(envelope, LicenseAmendment{ExpirationTime: &expirationTime})
+	}
+
+	_, err = amend(envelope, expiration.Add(12*time.Hour))
+	require.NoError(t, err)
+	_, err = amend(envelope, expiration.Add(36*time.Hour))
+	require.ErrorIs(t, err, ErrRenewalRejected)
+	_, err = amend(envelope, expiration.Add(-time.Hour))
+	require.ErrorIs(t, err, ErrRenewalRejected)
+
+	// Amendments that leave the expiration time unchanged are not checked
+	plan := "SKU-PRO"
+	_, err = manager.AmendLicense(envelope, LicenseAmendment{ProductPlanUniqueID: &plan})
+	require.NoError(t, err)
+
+	// The lifetime is measured from the original issue time across amendments
+	amended, err := amend(envelope, expiration.Add(24*time.Hour))
+	require.NoError(t, err)
+	_, err = amend(amended, expiration.Add(36*time.Hour))
+	require.NoError(t, err)
+	_, err = amend(amended, expiration.Add(44*time.Hour))
+	require.ErrorIs(t, err, ErrRenewalRejected)
+}
+
 func TestGenerator_RenewLicenseBase64(t *testing.T) {
 	t.Parallel()
 
@@ -109,3 +230,307 @@ func TestGenerator_GetPublicCertificateBase64(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, certPEM, pem)
 }
+
+func TestGenerator_AmendLicense(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().UTC().Add(48*time.Hour))
+	require.NoError(t, err)
+	original := *envelope.License
+
+	plan := "SKU-PRO"
+	amended, err := manager.AmendLicense(envelope, LicenseAmendment{
+		ProductPlanUniqueID: &plan,
+		Features:            []string{"sso", "audit-log"},
+		Limits:              map[string]int64{"seats": 50},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, original, *envelope.License)
+	assert.NotEqual(t, original.ID, amended.License.ID)
+	assert.Equal(t, original.ID, amended.License.LineageID)
+	assert.Equal(t, original.ID, amended.License.SupersededLicenseID)
+	assert.Equal(t, original.Version+1, amended.License.Version)
+	assert.Equal(t, common.SignatureHash(envelope.Signature), amended.License.PreviousSignatureHash)
+	assert.Equal(t, "SKU-PRO", amended.License.ProductPlanUniqueID)
+	assert.Equal(t, "instance-1", amended.License.InstanceID)
+	assert.Equal(t, original.ExpirationTime, amended.License.ExpirationTime)
+	assert.True(t, amended.License.HasFeature("sso"))
+	seats, ok := amended.License.GetLimit("seats")
+	assert.True(t, ok)
+	assert.Equal(t, int64(50), seats)
+
+	// Downgrade keeps the lineage of the first license
+	plan = "SKU"
+	instance := "instance-2"
+	downgraded, err := manager.AmendLicense(amended, LicenseAmendment{
+		ProductPlanUniqueID: &plan,
+		InstanceID:          &instance,
+		Features:            []string{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, original.ID, downgraded.License.LineageID)
+	assert.Equal(t, amended.License.ID, downgraded.License.SupersededLicenseID)
+	assert.Equal(t, original.Version+2, downgraded.License.Version)
+	assert.Equal(t, "instance-2", downgraded.License.InstanceID)
+	assert.Empty(t, downgraded.License.Features)
+	assert.Equal(t, amended.License.Limits, downgraded.License.Limits)
+
+	// A disaster recovery instance is added to the license
+	withDR, err := manager.AmendLicense(downgraded, LicenseAmendment{InstanceIDs: []string{"instance-dr-*"}})
+	require.NoError(t, err)
+	assert.Equal(t, "instance-2", withDR.License.InstanceID)
+	assert.Equal(t, []string{"instance-dr-*"}, withDR.License.InstanceIDs)
+
+	_, err = manager.AmendLicense(downgraded, LicenseAmendment{InstanceIDs: []string{"instance-["}})
+	assert.ErrorIs(t, err, ErrInvalidAmendment)
+
+	_, err = manager.AmendLicense(envelope, LicenseAmendment{})
+	assert.Error(t, err)
+
+	_, err = manager.AmendLicense(nil, LicenseAmendment{ProductPlanUniqueID: &plan})
+	assert.Error(t, err)
+}
+
+func TestGenerator_AmendLicenseBase64(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+
+	licenseBase64, err := manager.GenerateLicenseBase64("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().UTC().Add(48*time.Hour))
+	require.NoError(t, err)
+
+	description := "product a pro"
+	amendedBase64, err := manager.AmendLicenseBase64(licenseBase64, LicenseAmendment{Description: &description})
+	require.NoError(t, err)
+
+	decoded, err := common.DecodeLicenseEnvelopeFromBase64(licenseBase64)
+	require.NoError(t, err)
+	decodedAmended, err := common.DecodeLicenseEnvelopeFromBase64(amendedBase64)
+	require.NoError(t, err)
+
+	assert.Equal(t, decoded.License.ID, decodedAmended.License.LineageID)
+	assert.Equal(t, "product a pro", decodedAmended.License.Description)
+}
+
+func TestGenerator_ConcurrentSuccessors(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryIssuanceStore()
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM, WithIssuanceStore(store), WithTimestampAuthority(slowAuthority(10*time.Millisecond)))
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+
+	expiration := time.Now().UTC().Add(48 * time.Hour)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", expiration)
+	require.NoError(t, err)
+
+	// Renewals and amendments racing on the same license record a single successor
+	const attempts = 8
+	errs := make(chan error, attempts)
+	var wg sync.WaitGroup
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				_, err := manager.RenewLicense(envelope, expiration.Add(time.Duration(i+1)*time.Hour))
+				errs <- err
+				return
+			}
+			description := fmt.Sprintf("amendment %d", i)
+			_, err := manager.AmendLicense(envelope, LicenseAmendment{Description: &description})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, ErrLicenseSuperseded)
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestGenerator_IssuanceStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryIssuanceStore()
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM, WithIssuanceStore(store))
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+
+	expiration := time.Now().UTC().Add(48 * time.Hour)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", expiration)
+	require.NoError(t, err)
+
+	record, err := store.Get(envelope.License.ID)
+	require.NoError(t, err)
+	assert.Equal(t, IssuanceEventIssued, record.Event)
+	assert.Equal(t, "orgId", record.OrganizationID)
+	stored, err := record.GetEnvelope()
+	require.NoError(t, err)
+	assert.Equal(t, envelope.Signature, stored.Signature)
+
+	renewed, err := manager.RenewLicense(envelope, expiration.Add(24*time.Hour))
+	require.NoError(t, err)
+	record, err = store.Get(envelope.License.ID)
+	require.NoError(t, err)
+	assert.Equal(t, IssuanceEventRenewed, record.Event)
+	assert.Equal(t, renewed.License.Version, record.Version)
+
+	plan := "SKU-PRO"
+	amended, err := manager.AmendLicense(renewed, LicenseAmendment{ProductPlanUniqueID: &plan})
+	require.NoError(t, err)
+
+	live, err := store.Query(IssuanceQuery{OrganizationID: "orgId", LiveAt: time.Now()})
+	require.NoError(t, err)
+	require.Len(t, live, 1)
+	assert.Equal(t, amended.License.ID, live[0].LicenseID)
+	assert.Equal(t, IssuanceEventAmended, live[0].Event)
+
+	require.NoError(t, manager.RevokeLicense(amended.License.ID, "customer churned"))
+	record, err = store.Get(amended.License.ID)
+	require.NoError(t, err)
+	assert.Equal(t, IssuanceEventRevoked, record.Event)
+	assert.True(t, record.IsRevoked())
+	assert.Equal(t, "customer churned", record.RevocationReason)
+
+	live, err = store.Query(IssuanceQuery{OrganizationID: "orgId", LiveAt: time.Now()})
+	require.NoError(t, err)
+	assert.Empty(t, live)
+
+	// Revoked and superseded licenses cannot be renewed or amended, and keep their revocation
+	_, err = manager.RenewLicense(amended, expiration.Add(48*time.Hour))
+	require.ErrorIs(t, err, ErrLicenseRevoked)
+	_, err = manager.AmendLicense(amended, LicenseAmendment{Description: &plan})
+	require.ErrorIs(t, err, ErrLicenseRevoked)
+	_, err = manager.RenewLicense(renewed, expiration.Add(48*time.Hour))
+	require.ErrorIs(t, err, ErrLicenseSuperseded)
+	_, err = manager.AmendLicense(envelope, LicenseAmendment{Description: &plan})
+	require.ErrorIs(t, err, ErrLicenseSuperseded)
+	record, err = store.Get(amended.License.ID)
+	require.NoError(t, err)
+	assert.True(t, record.IsRevoked())
+
+	assert.ErrorIs(t, manager.RevokeLicense("unknown", ""), ErrLicenseNotFound)
+}
+
+func TestGenerator_RevokeLicenseWithoutStore(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+
+	assert.Error(t, manager.RevokeLicense("license-1", ""))
+}
+
+func TestGenerator_IssueTimeAttestation(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+
+	before := time.Now()
+	envelope, err := manager.IssueTimeAttestation()
+	require.NoError(t, err)
+	assert.True(t, envelope.IsValid())
+	assert.Equal(t, common.TimeAttestationType, envelope.Attestation.Type)
+
+	issueTime, err := envelope.Attestation.GetIssueTime()
+	require.NoError(t, err)
+	assert.WithinDuration(t, before, issueTime, time.Minute)
+
+	encoded, err := manager.IssueTimeAttestationBase64()
+	require.NoError(t, err)
+	decoded, err := common.DecodeTimeAttestationEnvelopeFromBase64(encoded)
+	require.NoError(t, err)
+	assert.True(t, decoded.IsValid())
+
+	// A generator without a key fails instead of panicking
+	keyless := &Manager{certPEM: certPEM}
+	_, err = keyless.IssueTimeAttestation()
+	require.Error(t, err)
+	_, err = keyless.IssueTimeAttestationBase64()
+	require.Error(t, err)
+}
+
+// contextAuthority records the context it is called with.
+type contextAuthority struct {
+	ctx context.Context
+}
+
+func (a *contextAuthority) Timestamp(ctx context.Context, data []byte) ([]byte, error) {
+	a.ctx = ctx
+	return []byte("token"), nil
+}
+
+type contextKey struct{}
+
+// slowAuthority delays every timestamp, widening the window between checking a license and recording its successor.
+type slowAuthority time.Duration
+
+func (a slowAuthority) Timestamp(ctx context.Context, data []byte) ([]byte, error) {
+	time.Sleep(time.Duration(a))
+	return []byte("token"), nil
+}
+
+func TestGenerator_Context(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryIssuanceStore()
+	tsa := &contextAuthority{}
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM, WithIssuanceStore(store), WithTimestampAuthority(tsa))
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+	expiration := time.Now().Add(24 * time.Hour)
+
+	// The context reaches the timestamp authority
+	ctx := context.WithValue(context.Background(), contextKey{}, "request")
+	envelope, err := manager.GenerateLicenseContext(ctx, "orgId", "SKU", "instance-1", "subs-1", "product a", expiration)
+	require.NoError(t, err)
+	require.NotNil(t, tsa.ctx)
+	assert.Equal(t, "request", tsa.ctx.Value(contextKey{}))
+
+	// A cancelled context stops every operation before signing or recording
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = manager.GenerateLicenseContext(cancelled, "orgId", "SKU", "instance-1", "subs-1", "product a", expiration)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = manager.GenerateLicenseBase64Context(cancelled, "orgId", "SKU", "instance-1", "subs-1", "product a", expiration)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = manager.RenewLicenseContext(cancelled, envelope, expiration.Add(time.Hour))
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = manager.RenewLicenseBase64Context(cancelled, envelope.EncodeBase64(), expiration.Add(time.Hour))
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = manager.AmendLicenseContext(cancelled, envelope, LicenseAmendment{Features: []string{"sso"}})
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, manager.RevokeLicenseContext(cancelled, envelope.License.ID, "unpaid"), context.Canceled)
+	_, err = manager.IssueTimeAttestationContext(cancelled)
+	require.ErrorIs(t, err, context.Canceled)
+
+	records, err := store.Query(IssuanceQuery{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.False(t, records[0].IsRevoked())
+}
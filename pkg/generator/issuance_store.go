@@ -0,0 +1,234 @@
+package generator
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/pkg/errors"
+)
+
+type IssuanceEvent string
+
+const (
+	IssuanceEventIssued  IssuanceEvent = "issued"
+	IssuanceEventRenewed IssuanceEvent = "renewed"
+	IssuanceEventAmended IssuanceEvent = "amended"
+	IssuanceEventRevoked IssuanceEvent = "revoked"
+)
+
+// IssuanceRecord is the ledger entry of a license. It reflects the latest event recorded for the license ID.
+type IssuanceRecord struct {
+	LicenseID           string        `json:"licenseID"`
+	LineageID           string        `json:"lineageID,omitempty"`
+	OrganizationID      string        `json:"organizationID,omitempty"`
+	ProductPlanUniqueID string        `json:"productPlanUniqueID,omitempty"`
+	InstanceID          string        `json:"instanceID,omitempty"`
+	InstanceIDs         []string      `json:"instanceIDs,omitempty"`
+	SubscriptionID      string        `json:"subscriptionID,omitempty"`
+	Version             uint64        `json:"version,omitempty"`
+	Event               IssuanceEvent `json:"event"`
+	CreationTime        time.Time     `json:"creationTime"`
+	ExpirationTime      time.Time     `json:"expirationTime"`
+	RecordedAt          time.Time     `json:"recordedAt"`
+	SupersededLicenseID string        `json:"supersededLicenseID,omitempty"`
+	SupersededBy        string        `json:"supersededBy,omitempty"`
+	RevokedAt           time.Time     `json:"revokedAt,omitzero"`
+	RevocationReason    string        `json:"revocationReason,omitempty"`
+	// Envelope is the base64 encoded license envelope as it was issued.
+	Envelope string `json:"envelope,omitempty"`
+}
+
+func NewIssuanceRecord(envelope *common.LicenseEnvelope, event IssuanceEvent, recordedAt time.Time) (*IssuanceRecord, error) {
+	if !envelope.IsValid() {
+		return nil, ErrEnvelopeInvalid
+	}
+
+	license := envelope.License
+	creationTime, err := license.GetCreationTime()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid creation time")
+	}
+	expirationTime, err := license.GetExpirationTime()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid expiration time")
+	}
+
+	return &IssuanceRecord{
+		LicenseID:           license.ID,
+		LineageID:           license.GetLineageID(),
+		OrganizationID:      license.OrganizationID,
+		ProductPlanUniqueID: license.ProductPlanUniqueID,
+		InstanceID:          license.InstanceID,
+		InstanceIDs:         slices.Clone(license.InstanceIDs),
+		SubscriptionID:      license.SubscriptionID,
+		Version:             license.Version,
+		Event:               event,
+		CreationTime:        creationTime,
+		ExpirationTime:      expirationTime,
+		RecordedAt:          recordedAt.UTC(),
+		SupersededLicenseID: license.SupersededLicenseID,
+		Envelope:            envelope.EncodeBase64(),
+	}, nil
+}
+
+// matchesInstance reports whether the license is bound to the instance, like License.MatchesInstance.
+func (r *IssuanceRecord) matchesInstance(instanceID string) bool {
+	license := common.License{InstanceID: r.InstanceID, InstanceIDs: r.InstanceIDs}
+	matched, err := license.MatchesInstance(instanceID)
+	return err == nil && matched
+}
+
+func (r *IssuanceRecord) IsRevoked() bool {
+	return !r.RevokedAt.IsZero()
+}
+
+func (r *IssuanceRecord) IsSuperseded() bool {
+	return r.SupersededBy != ""
+}
+
+// IsLiveAt reports whether the license is usable at t: not revoked, not superseded and not expired.
+func (r *IssuanceRecord) IsLiveAt(t time.Time) bool {
+	return !r.IsRevoked() && !r.IsSuperseded() && !t.After(r.ExpirationTime)
+}
+
+func (r *IssuanceRecord) GetEnvelope() (*common.LicenseEnvelope, error) {
+	return common.DecodeLicenseEnvelopeFromBase64(r.Envelope)
+}
+
+// IssuanceQuery selects ledger records. Empty fields are not used for filtering.
+type IssuanceQuery struct {
+	OrganizationID      string `json:"organizationID,omitempty"`
+	SubscriptionID      string `json:"subscriptionID,omitempty"`
+	InstanceID          string `json:"instanceID,omitempty"`
+	ProductPlanUniqueID string `json:"productPlanUniqueID,omitempty"`
+	LineageID           string `json:"lineageID,omitempty"`
+	// ExpiresAfter and ExpiresBefore bound the expiration time of the returned licenses (inclusive).
+	ExpiresAfter  time.Time `json:"expiresAfter,omitzero"`
+	ExpiresBefore time.Time `json:"expiresBefore,omitzero"`
+	// LiveAt only returns licenses that are live at the given time.
+	LiveAt            time.Time `json:"liveAt,omitzero"`
+	IncludeRevoked    bool      `json:"includeRevoked,omitempty"`
+	IncludeSuperseded bool      `json:"includeSuperseded,omitempty"`
+}
+
+func (q IssuanceQuery) Matches(record *IssuanceRecord) bool {
+	if q.OrganizationID != "" && record.OrganizationID != q.OrganizationID {
+		return false
+	}
+	if q.SubscriptionID != "" && record.SubscriptionID != q.SubscriptionID {
+		return false
+	}
+	if q.InstanceID != "" && !record.matchesInstance(q.InstanceID) {
+		return false
+	}
+	if q.ProductPlanUniqueID != "" && record.ProductPlanUniqueID != q.ProductPlanUniqueID {
+		return false
+	}
+	if q.LineageID != "" && record.LineageID != q.LineageID {
+		return false
+	}
+	if !q.ExpiresAfter.IsZero() && record.ExpirationTime.Before(q.ExpiresAfter) {
+		return false
+	}
+	if !q.ExpiresBefore.IsZero() && record.ExpirationTime.After(q.ExpiresBefore) {
+		return false
+	}
+	if !q.LiveAt.IsZero() && !record.IsLiveAt(q.LiveAt) {
+		return false
+	}
+	if !q.IncludeRevoked && record.IsRevoked() {
+		return false
+	}
+	if !q.IncludeSuperseded && record.IsSuperseded() {
+		return false
+	}
+	return true
+}
+
+// IssuanceStore keeps track of every license issued by a generator.
+type IssuanceStore interface {
+	// Record stores the latest state of a license, replacing any previous record with the same license ID.
+	Record(record IssuanceRecord) error
+	// Get returns the record of a license, or ErrLicenseNotFound.
+	Get(licenseID string) (*IssuanceRecord, error)
+	// Query returns the records matching the query ordered by creation time.
+	Query(query IssuanceQuery) ([]IssuanceRecord, error)
+}
+
+type MemoryIssuanceStore struct {
+	mu      sync.RWMutex
+	records map[string]IssuanceRecord
+}
+
+func NewMemoryIssuanceStore() *MemoryIssuanceStore {
+	return &MemoryIssuanceStore{
+		records: map[string]IssuanceRecord{},
+	}
+}
+
+func (s *MemoryIssuanceStore) Record(record IssuanceRecord) error {
+	if record.LicenseID == "" {
+		return errors.New("license id is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apply(record)
+	return nil
+}
+
+func (s *MemoryIssuanceStore) apply(record IssuanceRecord) {
+	if previous, ok := s.records[record.LicenseID]; ok {
+		// Keep the supersession link when a superseded license is renewed or revoked
+		if record.SupersededBy == "" {
+			record.SupersededBy = previous.SupersededBy
+		}
+		// A revocation is final, later events for the license do not clear it
+		if !record.IsRevoked() {
+			record.RevokedAt = previous.RevokedAt
+			record.RevocationReason = previous.RevocationReason
+		}
+	}
+	s.records[record.LicenseID] = record
+
+	if record.SupersededLicenseID != "" {
+		if superseded, ok := s.records[record.SupersededLicenseID]; ok {
+			superseded.SupersededBy = record.LicenseID
+			s.records[record.SupersededLicenseID] = superseded
+		}
+	}
+}
+
+func (s *MemoryIssuanceStore) Get(licenseID string) (*IssuanceRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.records[licenseID]
+	if !ok {
+		return nil, errors.Wrap(ErrLicenseNotFound, licenseID)
+	}
+	return &record, nil
+}
+
+func (s *MemoryIssuanceStore) Query(query IssuanceQuery) ([]IssuanceRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := make([]IssuanceRecord, 0)
+	for _, record := range s.records {
+		if query.Matches(&record) {
+			records = append(records, record)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].CreationTime.Equal(records[j].CreationTime) {
+			return records[i].CreationTime.Before(records[j].CreationTime)
+		}
+		return records[i].LicenseID < records[j].LicenseID
+	})
+	return records, nil
+}
@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryIssuanceStore_Query(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryIssuanceStore()
+
+	records := []IssuanceRecord{
+		{LicenseID: "l1", OrganizationID: "org-1", SubscriptionID: "subs-1", InstanceID: "instance-1", ProductPlanUniqueID: "SKU", CreationTime: base, ExpirationTime: base.AddDate(0, 1, 0)},
+		{LicenseID: "l2", OrganizationID: "org-1", SubscriptionID: "subs-2", InstanceID: "instance-2", ProductPlanUniqueID: "SKU-PRO", CreationTime: base.Add(time.Hour), ExpirationTime: base.AddDate(0, 2, 0)},
+		{LicenseID: "l3", OrganizationID: "org-2", SubscriptionID: "subs-3", InstanceID: "instance-3", InstanceIDs: []string{"instance-dr-*"}, ProductPlanUniqueID: "SKU", CreationTime: base.Add(2 * time.Hour), ExpirationTime: base.AddDate(0, 3, 0)},
+	}
+	for _, record := range records {
+		require.NoError(t, store.Record(record))
+	}
+
+	licenseIDs := func(query IssuanceQuery) []string {
+		result, err := store.Query(query)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(result))
+		for _, record := range result {
+			ids = append(ids, record.LicenseID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"l1", "l2", "l3"}, licenseIDs(IssuanceQuery{}))
+	assert.Equal(t, []string{"l1", "l2"}, licenseIDs(IssuanceQuery{OrganizationID: "org-1"}))
+	assert.Equal(t, []string{"l2"}, licenseIDs(IssuanceQuery{SubscriptionID: "subs-2"}))
+	assert.Equal(t, []string{"l3"}, licenseIDs(IssuanceQuery{InstanceID: "instance-3"}))
+	assert.Equal(t, []string{"l3"}, licenseIDs(IssuanceQuery{InstanceID: "instance-dr-1"}))
+	assert.Equal(t, []string{"l1", "l3"}, licenseIDs(IssuanceQuery{ProductPlanUniqueID: "SKU"}))
+	assert.Equal(t, []string{"l2", "l3"}, licenseIDs(IssuanceQuery{ExpiresAfter: base.AddDate(0, 1, 1)}))
+	assert.Equal(t, []string{"l1", "l2"}, licenseIDs(IssuanceQuery{ExpiresBefore: base.AddDate(0, 2, 0)}))
+	assert.Equal(t, []string{"l3"}, licenseIDs(IssuanceQuery{LiveAt: base.AddDate(0, 2, 1)}))
+
+	// Revoked licenses are hidden unless requested
+	revoked := records[0]
+	revoked.Event = IssuanceEventRevoked
+	revoked.RevokedAt = base.Add(time.Minute)
+	require.NoError(t, store.Record(revoked))
+	assert.Equal(t, []string{"l2"}, licenseIDs(IssuanceQuery{OrganizationID: "org-1"}))
+	assert.Equal(t, []string{"l1", "l2"}, licenseIDs(IssuanceQuery{OrganizationID: "org-1", IncludeRevoked: true}))
+
+	// A later event does not clear the revocation
+	renewed := records[0]
+	renewed.Event = IssuanceEventRenewed
+	renewed.Version = 2
+	require.NoError(t, store.Record(renewed))
+	record, err := store.Get("l1")
+	require.NoError(t, err)
+	assert.True(t, record.IsRevoked())
+	assert.Equal(t, IssuanceEventRenewed, record.Event)
+
+	// Superseded licenses are hidden unless requested
+	amended := IssuanceRecord{LicenseID: "l4", LineageID: "l3", SupersededLicenseID: "l3", OrganizationID: "org-2", CreationTime: base.Add(3 * time.Hour), ExpirationTime: base.AddDate(0, 3, 0)}
+	require.NoError(t, store.Record(amended))
+	assert.Equal(t, []string{"l4"}, licenseIDs(IssuanceQuery{OrganizationID: "org-2"}))
+	assert.Equal(t, []string{"l3", "l4"}, licenseIDs(IssuanceQuery{OrganizationID: "org-2", IncludeSuperseded: true}))
+
+	record, err = store.Get("l3")
+	require.NoError(t, err)
+	assert.Equal(t, "l4", record.SupersededBy)
+
+	_, err = store.Get("unknown")
+	assert.ErrorIs(t, err, ErrLicenseNotFound)
+
+	assert.Error(t, store.Record(IssuanceRecord{}))
+}
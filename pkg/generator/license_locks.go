@@ -0,0 +1,43 @@
+package generator
+
+import "sync"
+
+// licenseLocks serializes the operations building on the same license, so that checking that a license is
+// current, signing its next version and recording it happen atomically within a Manager.
+type licenseLocks struct {
+	mu    sync.Mutex
+	locks map[string]*licenseLock
+}
+
+type licenseLock struct {
+	sync.Mutex
+	holders int
+}
+
+// lock blocks until no other operation holds the license, and returns the function releasing it.
+func (l *licenseLocks) lock(licenseID string) (unlock func()) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*licenseLock{}
+	}
+	lock, ok := l.locks[licenseID]
+	if !ok {
+		lock = &licenseLock{}
+		l.locks[licenseID] = lock
+	}
+	lock.holders++
+	l.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		// Forget the lock once released by every operation, so the map does not grow with every license
+		lock.holders--
+		if lock.holders == 0 {
+			delete(l.locks, licenseID)
+		}
+	}
+}
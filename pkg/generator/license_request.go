@@ -0,0 +1,222 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+)
+
+// IDStrategy generates the ID of a new license.
+type IDStrategy func() string
+
+// RandomIDStrategy generates random (version 4) UUIDs. It is the default strategy.
+func RandomIDStrategy() string {
+	return uuid.NewString()
+}
+
+// TimeOrderedIDStrategy generates time ordered (version 7) UUIDs.
+func TimeOrderedIDStrategy() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
+	}
+	return id.String()
+}
+
+// FixedIDStrategy always returns the given ID, for callers that allocate license IDs themselves.
+func FixedIDStrategy(id string) IDStrategy {
+	return func() string {
+		return id
+	}
+}
+
+type OutputFormat string
+
+const (
+	OutputFormatBase64 OutputFormat = "base64"
+	OutputFormatJSON   OutputFormat = "json"
+)
+
+func (f OutputFormat) Encode(envelope *common.LicenseEnvelope) (string, error) {
+	switch f {
+	case OutputFormatBase64, "":
+		return envelope.EncodeBase64(), nil
+	case OutputFormatJSON:
+		return envelope.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported output format %s", f)
+	}
+}
+
+// LicenseRequest holds every claim of a license to generate. Build it with NewLicenseRequest and
+// LicenseOption values, or fill the fields directly.
+type LicenseRequest struct {
+	OrganizationID      string            `json:"organizationID,omitempty"`
+	ProductPlanUniqueID string            `json:"productPlanUniqueID,omitempty"`
+	InstanceID          string            `json:"instanceID,omitempty"`
+	InstanceIDs         []string          `json:"instanceIDs,omitempty"`
+	SubscriptionID      string            `json:"subscriptionID,omitempty"`
+	Description         string            `json:"description,omitempty"`
+	ExpirationTime      time.Time         `json:"expirationTime"`
+	IssueTime           time.Time         `json:"issueTime,omitzero"`
+	NotBefore           time.Time         `json:"notBefore,omitzero"`
+	Features            []string          `json:"features,omitempty"`
+	Limits              map[string]int64  `json:"limits,omitempty"`
+	Metadata            map[string]string `json:"metadata,omitempty"`
+	OutputFormat        OutputFormat      `json:"outputFormat,omitempty"`
+	IDStrategy          IDStrategy        `json:"-"`
+
+	// HostFingerprint binds the license to a host, see the fingerprint package.
+	HostFingerprint *common.HostFingerprint `json:"hostFingerprint,omitempty"`
+}
+
+type LicenseOption func(*LicenseRequest)
+
+func NewLicenseRequest(orgId, productPlanUniqueID string, expirationDate time.Time, opts ...LicenseOption) *LicenseRequest {
+	request := &LicenseRequest{
+		OrganizationID:      orgId,
+		ProductPlanUniqueID: productPlanUniqueID,
+		ExpirationTime:      expirationDate,
+	}
+	for _, opt := range opts {
+		opt(request)
+	}
+	return request
+}
+
+func WithInstanceID(instanceId string) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.InstanceID = instanceId
+	}
+}
+
+// WithInstanceIDs binds the license to several instances. Entries are instance IDs or path.Match patterns.
+func WithInstanceIDs(instanceIDs ...string) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.InstanceIDs = append(r.InstanceIDs, instanceIDs...)
+	}
+}
+
+// WithHostFingerprint binds the license to the host the fingerprint was computed on.
+func WithHostFingerprint(fingerprint *common.HostFingerprint) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.HostFingerprint = fingerprint
+	}
+}
+
+func WithSubscriptionID(subscriptionId string) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.SubscriptionID = subscriptionId
+	}
+}
+
+func WithDescription(description string) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.Description = description
+	}
+}
+
+// WithIssueTime sets the creation time of the license. Defaults to the time the license is generated.
+func WithIssueTime(issueTime time.Time) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.IssueTime = issueTime
+	}
+}
+
+func WithNotBefore(notBefore time.Time) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.NotBefore = notBefore
+	}
+}
+
+func WithFeatures(features ...string) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.Features = append(r.Features, features...)
+	}
+}
+
+func WithLimit(name string, limit int64) LicenseOption {
+	return func(r *LicenseRequest) {
+		if r.Limits == nil {
+			r.Limits = map[string]int64{}
+		}
+		r.Limits[name] = limit
+	}
+}
+
+func WithLimits(limits map[string]int64) LicenseOption {
+	return func(r *LicenseRequest) {
+		for name, limit := range limits {
+			WithLimit(name, limit)(r)
+		}
+	}
+}
+
+func WithMetadata(key, value string) LicenseOption {
+	return func(r *LicenseRequest) {
+		if r.Metadata == nil {
+			r.Metadata = map[string]string{}
+		}
+		r.Metadata[key] = value
+	}
+}
+
+func WithIDStrategy(strategy IDStrategy) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.IDStrategy = strategy
+	}
+}
+
+func WithOutputFormat(format OutputFormat) LicenseOption {
+	return func(r *LicenseRequest) {
+		r.OutputFormat = format
+	}
+}
+
+func (r *LicenseRequest) Validate() error {
+	if r.ExpirationTime.IsZero() {
+		return fmt.Errorf("expiration time is required")
+	}
+	if !r.NotBefore.IsZero() && !r.NotBefore.Before(r.ExpirationTime) {
+		return fmt.Errorf("not before time %s must be before expiration time %s", r.NotBefore.UTC().Format(time.RFC3339), r.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+	if err := common.ValidateInstanceIDPatterns(r.InstanceIDs); err != nil {
+		return err
+	}
+	if r.HostFingerprint != nil {
+		if err := r.HostFingerprint.Validate(); err != nil {
+			return err
+		}
+	}
+	switch r.OutputFormat {
+	case "", OutputFormatBase64, OutputFormatJSON:
+	default:
+		return fmt.Errorf("unsupported output format %s", r.OutputFormat)
+	}
+	return nil
+}
+
+func (r *LicenseRequest) newLicense(now time.Time) *common.License {
+	issueTime := r.IssueTime
+	if issueTime.IsZero() {
+		issueTime = now
+	}
+
+	license := common.NewLicense(r.OrganizationID, r.ProductPlanUniqueID, r.InstanceID, r.SubscriptionID, r.Description, issueTime, r.ExpirationTime)
+	if r.IDStrategy != nil {
+		license.ID = r.IDStrategy()
+	}
+	if !r.NotBefore.IsZero() {
+		license.NotBefore = r.NotBefore.UTC().Format(time.RFC3339)
+	}
+	license.InstanceIDs = slices.Clone(r.InstanceIDs)
+	license.HostFingerprint = r.HostFingerprint.Clone()
+	license.Features = slices.Clone(r.Features)
+	license.Limits = maps.Clone(r.Limits)
+	license.Metadata = maps.Clone(r.Metadata)
+	return license
+}
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLicenseRequest(t *testing.T) {
+	t.Parallel()
+
+	expiration := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	issueTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	notBefore := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	request := NewLicenseRequest(
+		"orgId",
+		"SKU",
+		expiration,
+		WithInstanceID("instance-1"),
+		WithInstanceIDs("instance-dr-*"),
+		WithSubscriptionID("subs-1"),
+		WithDescription("product a"),
+		WithIssueTime(issueTime),
+		WithNotBefore(notBefore),
+		WithFeatures("sso"),
+		WithFeatures("audit-log"),
+		WithLimits(map[string]int64{"seats": 10}),
+		WithLimit("nodes", 3),
+		WithMetadata("region", "us-east-1"),
+		WithIDStrategy(FixedIDStrategy("license-1")),
+		WithOutputFormat(OutputFormatJSON),
+	)
+	require.NoError(t, request.Validate())
+
+	license := request.newLicense(time.Now())
+	assert.Equal(t, "license-1", license.ID)
+	assert.Equal(t, "orgId", license.OrganizationID)
+	assert.Equal(t, "SKU", license.ProductPlanUniqueID)
+	assert.Equal(t, "instance-1", license.InstanceID)
+	assert.Equal(t, []string{"instance-dr-*"}, license.InstanceIDs)
+	assert.Equal(t, "subs-1", license.SubscriptionID)
+	assert.Equal(t, "product a", license.Description)
+	assert.Equal(t, "2025-01-01T00:00:00Z", license.CreationTime)
+	assert.Equal(t, "2025-02-01T00:00:00Z", license.NotBefore)
+	assert.Equal(t, "2026-01-01T00:00:00Z", license.ExpirationTime)
+	assert.Equal(t, []string{"sso", "audit-log"}, license.Features)
+	assert.Equal(t, map[string]int64{"seats": 10, "nodes": 3}, license.Limits)
+	assert.Equal(t, map[string]string{"region": "us-east-1"}, license.Metadata)
+	assert.Equal(t, uint64(1), license.Version)
+}
+
+func TestLicenseRequest_JSON(t *testing.T) {
+	t.Parallel()
+
+	expiration := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Unset times are omitted and decode back as unset
+	request := NewLicenseRequest("orgId", "SKU", expiration)
+	data, err := json.Marshal(request)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "issueTime")
+	assert.NotContains(t, string(data), "notBefore")
+	var decoded LicenseRequest
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, decoded.IssueTime.IsZero())
+	assert.True(t, decoded.NotBefore.IsZero())
+	assert.Equal(t, expiration, decoded.ExpirationTime)
+
+	request = NewLicenseRequest("orgId", "SKU", expiration, WithNotBefore(expiration.AddDate(0, -1, 0)))
+	data, err = json.Marshal(request)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, expiration.AddDate(0, -1, 0), decoded.NotBefore)
+}
+
+func TestLicenseRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	expiration := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, NewLicenseRequest("orgId", "SKU", expiration).Validate())
+	assert.Error(t, NewLicenseRequest("orgId", "SKU", time.Time{}).Validate())
+	assert.Error(t, NewLicenseRequest("orgId", "SKU", expiration, WithNotBefore(expiration)).Validate())
+	assert.Error(t, NewLicenseRequest("orgId", "SKU", expiration, WithOutputFormat("xml")).Validate())
+	assert.NoError(t, NewLicenseRequest("orgId", "SKU", expiration, WithInstanceIDs("instance-1", "instance-dr-*")).Validate())
+	assert.Error(t, NewLicenseRequest("orgId", "SKU", expiration, WithInstanceIDs("instance-[")).Validate())
+	fingerprint := &common.HostFingerprint{Factors: map[string]string{"machine_id": "hash"}}
+	assert.NoError(t, NewLicenseRequest("orgId", "SKU", expiration, WithHostFingerprint(fingerprint)).Validate())
+	assert.Error(t, NewLicenseRequest("orgId", "SKU", expiration, WithHostFingerprint(&common.HostFingerprint{})).Validate())
+}
+
+func TestIDStrategies(t *testing.T) {
+	t.Parallel()
+
+	assert.NotEqual(t, RandomIDStrategy(), RandomIDStrategy())
+	first := TimeOrderedIDStrategy()
+	second := TimeOrderedIDStrategy()
+	assert.NotEqual(t, first, second)
+	assert.Less(t, first, second)
+	assert.Equal(t, "license-1", FixedIDStrategy("license-1")())
+}
+
+func TestGenerator_GenerateLicenseEncoded(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(ContextGenerator)
+	require.True(t, ok)
+
+	expiration := time.Now().UTC().Add(48 * time.Hour)
+
+	licenseJSON, err := manager.GenerateLicenseEncoded(NewLicenseRequest("orgId", "SKU", expiration, WithOutputFormat(OutputFormatJSON), WithFeatures("sso")))
+	require.NoError(t, err)
+	envelope, err := common.DecodeLicenseEnvelopeFromString(licenseJSON)
+	require.NoError(t, err)
+	assert.True(t, envelope.License.HasFeature("sso"))
+
+	licenseBase64, err := manager.GenerateLicenseEncoded(NewLicenseRequest("orgId", "SKU", expiration))
+	require.NoError(t, err)
+	envelope, err = common.DecodeLicenseEnvelopeFromBase64(licenseBase64)
+	require.NoError(t, err)
+	assert.Equal(t, "orgId", envelope.License.OrganizationID)
+
+	_, err = manager.GenerateLicenseEncoded(NewLicenseRequest("orgId", "SKU", expiration, WithIDStrategy(FixedIDStrategy(""))))
+	assert.Error(t, err)
+
+	_, err = manager.GenerateLicenseFromRequest(nil)
+	assert.Error(t, err)
+}
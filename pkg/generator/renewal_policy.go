@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/pkg/errors"
+)
+
+// RenewalPolicy limits how far a license can be extended on renewal, or by amending its expiration time.
+// Zero durations mean no limit.
+type RenewalPolicy struct {
+	// MaxExtension is the maximum amount of time a single renewal can add, measured from the later of
+	// the current expiration time and the renewal time.
+	MaxExtension time.Duration `json:"maxExtension,omitempty"`
+	// MaxLifetime is the maximum amount of time between the original issue time and the new expiration time.
+	MaxLifetime time.Duration `json:"maxLifetime,omitempty"`
+	// AllowShortening allows a renewal to move the expiration time earlier than it currently is.
+	AllowShortening bool `json:"allowShortening,omitempty"`
+}
+
+func (p RenewalPolicy) Check(license *common.License, expirationDate time.Time, now time.Time) error {
+	if license == nil {
+		return fmt.Errorf("license is required")
+	}
+
+	currentExpiration, err := license.GetExpirationTime()
+	if err != nil {
+		return errors.Wrap(err, "invalid expiration time")
+	}
+
+	if !p.AllowShortening && expirationDate.Before(currentExpiration) {
+		return fmt.Errorf("renewal would shorten the license from %s to %s", currentExpiration.Format(time.RFC3339), expirationDate.UTC().Format(time.RFC3339))
+	}
+
+	if p.MaxExtension > 0 {
+		base := currentExpiration
+		if now.After(base) {
+			base = now
+		}
+		if extension := expirationDate.Sub(base); extension > p.MaxExtension {
+			return fmt.Errorf("renewal extension %s exceeds the maximum of %s", extension, p.MaxExtension)
+		}
+	}
+
+	if p.MaxLifetime > 0 {
+		originalIssueTime, err := license.GetOriginalIssueTime()
+		if err != nil {
+			return errors.Wrap(err, "invalid original issue time")
+		}
+		if lifetime := expirationDate.Sub(originalIssueTime); lifetime > p.MaxLifetime {
+			return fmt.Errorf("license lifetime %s exceeds the maximum of %s", lifetime, p.MaxLifetime)
+		}
+	}
+
+	return nil
+}
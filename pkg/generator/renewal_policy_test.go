@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenewalPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	license := &common.License{
+		ID:                "1234",
+		CreationTime:      "2025-05-01T00:00:00Z",
+		ExpirationTime:    "2025-07-01T00:00:00Z",
+		OriginalIssueTime: "2025-01-01T00:00:00Z",
+	}
+	currentExpiration, _ := license.GetExpirationTime()
+
+	// No limits
+	assert.NoError(t, RenewalPolicy{}.Check(license, currentExpiration.AddDate(5, 0, 0), now))
+
+	// Shortening
+	assert.Error(t, RenewalPolicy{}.Check(license, currentExpiration.Add(-time.Hour), now))
+	assert.NoError(t, RenewalPolicy{AllowShortening: true}.Check(license, currentExpiration.Add(-time.Hour), now))
+
+	// Maximum extension measured from the current expiration
+	policy := RenewalPolicy{MaxExtension: 30 * 24 * time.Hour}
+	assert.NoError(t, policy.Check(license, currentExpiration.AddDate(0, 0, 30), now))
+	assert.Error(t, policy.Check(license, currentExpiration.AddDate(0, 0, 31), now))
+
+	// Maximum extension measured from now when the license already expired
+	lateNow := currentExpiration.AddDate(0, 2, 0)
+	assert.NoError(t, policy.Check(license, lateNow.AddDate(0, 0, 30), lateNow))
+	assert.Error(t, policy.Check(license, lateNow.AddDate(0, 0, 31), lateNow))
+
+	// Maximum lifetime measured from the original issue time
+	policy = RenewalPolicy{MaxLifetime: 365 * 24 * time.Hour}
+	assert.NoError(t, policy.Check(license, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), now))
+	assert.Error(t, policy.Check(license, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), now))
+
+	// Invalid license
+	assert.Error(t, RenewalPolicy{}.Check(nil, currentExpiration, now))
+	assert.Error(t, RenewalPolicy{}.Check(&common.License{ExpirationTime: "invalid"}, currentExpiration, now))
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/certificate"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+)
+
+// IssueTimeAttestation signs the current time with the license signing key. Validators without a trustworthy
+// clock can use it as their time source.
+func (m *Manager) IssueTimeAttestation() (*common.TimeAttestationEnvelope, error) {
+	return m.IssueTimeAttestationContext(context.Background())
+}
+
+func (m *Manager) IssueTimeAttestationContext(ctx context.Context) (*common.TimeAttestationEnvelope, error) {
+	if m.key == nil {
+		return nil, fmt.Errorf("licenseKey is required to issue a time attestation")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	attestation := common.NewTimeAttestation(time.Now())
+	attestationBytes, err := attestation.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := certificate.Sign(m.key, attestationBytes)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewTimeAttestationEnvelope(attestation, signature), nil
+}
+
+func (m *Manager) IssueTimeAttestationBase64() (string, error) {
+	return m.IssueTimeAttestationBase64Context(context.Background())
+}
+
+func (m *Manager) IssueTimeAttestationBase64Context(ctx context.Context) (string, error) {
+	envelope, err := m.IssueTimeAttestationContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return envelope.EncodeBase64(), nil
+}
@@ -0,0 +1,50 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Authenticator decides whether a call may use the licensing methods.
+// A non-nil error rejects the call with codes.Unauthenticated.
+type Authenticator interface {
+	Authenticate(ctx context.Context) error
+}
+
+type AuthenticatorFunc func(ctx context.Context) error
+
+func (f AuthenticatorFunc) Authenticate(ctx context.Context) error {
+	return f(ctx)
+}
+
+// denyAll rejects every call, protecting servers that have no authenticator configured.
+var denyAll = AuthenticatorFunc(func(ctx context.Context) error {
+	return errors.Wrap(ErrUnauthenticated, "no authenticator is configured")
+})
+
+// NewBearerTokenAuthenticator accepts calls carrying one of the given tokens in the authorization metadata.
+func NewBearerTokenAuthenticator(tokens ...string) Authenticator {
+	return AuthenticatorFunc(func(ctx context.Context) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return errors.Wrap(ErrUnauthenticated, "missing bearer token")
+		}
+		token, ok := strings.CutPrefix(values[0], "Bearer ")
+		if !ok || token == "" {
+			return errors.Wrap(ErrUnauthenticated, "missing bearer token")
+		}
+		for _, expected := range tokens {
+			if expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+				return nil
+			}
+		}
+		return errors.Wrap(ErrUnauthenticated, "invalid bearer token")
+	})
+}
@@ -0,0 +1,200 @@
+package grpcserver
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/licensingpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func LicenseToProto(license *common.License) *licensingpb.License {
+	if license == nil {
+		return nil
+	}
+	return &licensingpb.License{
+		Id:                    license.ID,
+		CreationTime:          license.CreationTime,
+		ExpirationTime:        license.ExpirationTime,
+		Description:           license.Description,
+		InstanceId:            license.InstanceID,
+		SubscriptionId:        license.SubscriptionID,
+		ProductPlanUniqueId:   license.ProductPlanUniqueID,
+		OrganizationId:        license.OrganizationID,
+		Version:               license.Version,
+		OriginalIssueTime:     license.OriginalIssueTime,
+		PreviousSignatureHash: license.PreviousSignatureHash,
+		LineageId:             license.LineageID,
+		SupersededLicenseId:   license.SupersededLicenseID,
+		Features:              slices.Clone(license.Features),
+		Limits:                maps.Clone(license.Limits),
+		NotBefore:             license.NotBefore,
+		Metadata:              maps.Clone(license.Metadata),
+		InstanceIds:           slices.Clone(license.InstanceIDs),
+		HostFingerprint:       hostFingerprintToProto(license.HostFingerprint),
+	}
+}
+
+func LicenseFromProto(license *licensingpb.License) *common.License {
+	if license == nil {
+		return nil
+	}
+	return &common.License{
+		ID:                    license.GetId(),
+		CreationTime:          license.GetCreationTime(),
+		ExpirationTime:        license.GetExpirationTime(),
+		Description:           license.GetDescription(),
+		InstanceID:            license.GetInstanceId(),
+		SubscriptionID:        license.GetSubscriptionId(),
+		ProductPlanUniqueID:   license.GetProductPlanUniqueId(),
+		OrganizationID:        license.GetOrganizationId(),
+		Version:               license.GetVersion(),
+		OriginalIssueTime:     license.GetOriginalIssueTime(),
+		PreviousSignatureHash: license.GetPreviousSignatureHash(),
+		LineageID:             license.GetLineageId(),
+		SupersededLicenseID:   license.GetSupersededLicenseId(),
+		Features:              slices.Clone(license.GetFeatures()),
+		Limits:                maps.Clone(license.GetLimits()),
+		NotBefore:             license.GetNotBefore(),
+		Metadata:              maps.Clone(license.GetMetadata()),
+		InstanceIDs:           slices.Clone(license.GetInstanceIds()),
+		HostFingerprint:       hostFingerprintFromProto(license.GetHostFingerprint()),
+	}
+}
+
+func EnvelopeToProto(envelope *common.LicenseEnvelope) *licensingpb.LicenseEnvelope {
+	if envelope == nil {
+		return nil
+	}
+	return &licensingpb.LicenseEnvelope{
+		License:   LicenseToProto(envelope.License),
+		Signature: slices.Clone(envelope.Signature),
+		Timestamp: slices.Clone(envelope.Timestamp),
+	}
+}
+
+func EnvelopeFromProto(envelope *licensingpb.LicenseEnvelope) *common.LicenseEnvelope {
+	if envelope == nil {
+		return nil
+	}
+	licenseEnvelope := common.NewLicenseEnvelope(LicenseFromProto(envelope.GetLicense()), slices.Clone(envelope.GetSignature()))
+	licenseEnvelope.Timestamp = slices.Clone(envelope.GetTimestamp())
+	return licenseEnvelope
+}
+
+func issueRequestFromProto(request *licensingpb.IssueRequest) *generator.LicenseRequest {
+	return &generator.LicenseRequest{
+		OrganizationID:      request.GetOrganizationId(),
+		ProductPlanUniqueID: request.GetProductPlanUniqueId(),
+		InstanceID:          request.GetInstanceId(),
+		InstanceIDs:         request.GetInstanceIds(),
+		SubscriptionID:      request.GetSubscriptionId(),
+		Description:         request.GetDescription(),
+		ExpirationTime:      timeFromProto(request.GetExpirationTime()),
+		IssueTime:           timeFromProto(request.GetIssueTime()),
+		NotBefore:           timeFromProto(request.GetNotBefore()),
+		Features:            request.GetFeatures(),
+		Limits:              request.GetLimits(),
+		Metadata:            request.GetMetadata(),
+		HostFingerprint:     hostFingerprintFromProto(request.GetHostFingerprint()),
+	}
+}
+
+func amendmentFromProto(changes *licensingpb.LicenseAmendment) generator.LicenseAmendment {
+	var amendment generator.LicenseAmendment
+	if changes == nil {
+		return amendment
+	}
+	amendment.ProductPlanUniqueID = changes.ProductPlanUniqueId
+	amendment.InstanceID = changes.InstanceId
+	amendment.Description = changes.Description
+	if changes.GetExpirationTime() != nil {
+		expirationTime := timeFromProto(changes.GetExpirationTime())
+		amendment.ExpirationTime = &expirationTime
+	}
+	if changes.GetFeatures() != nil {
+		amendment.Features = append([]string{}, changes.GetFeatures().GetFeatures()...)
+	}
+	if changes.GetInstanceIds() != nil {
+		amendment.InstanceIDs = append([]string{}, changes.GetInstanceIds().GetInstanceIds()...)
+	}
+	if changes.GetLimits() != nil {
+		amendment.Limits = map[string]int64{}
+		maps.Copy(amendment.Limits, changes.GetLimits().GetLimits())
+	}
+	return amendment
+}
+
+func queryFromProto(request *licensingpb.ListRequest) generator.IssuanceQuery {
+	return generator.IssuanceQuery{
+		OrganizationID:      request.GetOrganizationId(),
+		SubscriptionID:      request.GetSubscriptionId(),
+		InstanceID:          request.GetInstanceId(),
+		ProductPlanUniqueID: request.GetProductPlanUniqueId(),
+		LineageID:           request.GetLineageId(),
+		ExpiresAfter:        timeFromProto(request.GetExpiresAfter()),
+		ExpiresBefore:       timeFromProto(request.GetExpiresBefore()),
+		LiveAt:              timeFromProto(request.GetLiveAt()),
+		IncludeRevoked:      request.GetIncludeRevoked(),
+		IncludeSuperseded:   request.GetIncludeSuperseded(),
+	}
+}
+
+func recordToProto(record generator.IssuanceRecord) *licensingpb.IssuanceRecord {
+	return &licensingpb.IssuanceRecord{
+		LicenseId:           record.LicenseID,
+		LineageId:           record.LineageID,
+		OrganizationId:      record.OrganizationID,
+		ProductPlanUniqueId: record.ProductPlanUniqueID,
+		InstanceId:          record.InstanceID,
+		InstanceIds:         slices.Clone(record.InstanceIDs),
+		SubscriptionId:      record.SubscriptionID,
+		Version:             record.Version,
+		Event:               string(record.Event),
+		CreationTime:        timeToProto(record.CreationTime),
+		ExpirationTime:      timeToProto(record.ExpirationTime),
+		RecordedAt:          timeToProto(record.RecordedAt),
+		SupersededLicenseId: record.SupersededLicenseID,
+		SupersededBy:        record.SupersededBy,
+		RevokedAt:           timeToProto(record.RevokedAt),
+		RevocationReason:    record.RevocationReason,
+		Encoded:             record.Envelope,
+	}
+}
+
+func hostFingerprintToProto(fingerprint *common.HostFingerprint) *licensingpb.HostFingerprint {
+	if fingerprint == nil {
+		return nil
+	}
+	return &licensingpb.HostFingerprint{
+		Factors:    maps.Clone(fingerprint.Factors),
+		MinMatches: int32(fingerprint.MinMatches),
+	}
+}
+
+func hostFingerprintFromProto(fingerprint *licensingpb.HostFingerprint) *common.HostFingerprint {
+	if fingerprint == nil {
+		return nil
+	}
+	return &common.HostFingerprint{
+		Factors:    maps.Clone(fingerprint.GetFactors()),
+		MinMatches: int(fingerprint.GetMinMatches()),
+	}
+}
+
+func timeFromProto(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
+
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
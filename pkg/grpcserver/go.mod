@@ -0,0 +1,21 @@
+module github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/grpcserver
+
+go 1.25.3
+
+require (
+	github.com/omnistrate-oss/omnistrate-licensing-sdk-go v0.1.0
+	github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/licensingpb v0.1.0
+	github.com/pkg/errors v0.9.1
+	github.com/stretchr/testify v1.12.1
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+)
@@ -0,0 +1,55 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/licensingpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const harnessBufferSize = 1 << 20
+
+// harness runs a LicensingService in process over an in-memory bufconn listener.
+type harness struct {
+	listener *bufconn.Listener
+	server   *grpc.Server
+	conn     *grpc.ClientConn
+	Client   licensingpb.LicensingServiceClient
+}
+
+func newHarness(service licensingpb.LicensingServiceServer, opts ...grpc.ServerOption) (*harness, error) {
+	listener := bufconn.Listen(harnessBufferSize)
+	server := grpc.NewServer(opts...)
+	licensingpb.RegisterLicensingServiceServer(server, service)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		server.Stop()
+		return nil, err
+	}
+
+	return &harness{
+		listener: listener,
+		server:   server,
+		conn:     conn,
+		Client:   licensingpb.NewLicensingServiceClient(conn),
+	}, nil
+}
+
+func (h *harness) Close() error {
+	err := h.conn.Close()
+	h.server.Stop()
+	return err
+}
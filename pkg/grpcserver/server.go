@@ -0,0 +1,204 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/licensingpb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Option func(*Server)
+
+// WithIssuanceStore enables the List RPC. It should be the store the generator records to.
+func WithIssuanceStore(store generator.IssuanceStore) Option {
+	return func(s *Server) {
+		s.store = store
+	}
+}
+
+// WithAuthenticator protects the Issue, Renew, Amend, Revoke and List methods.
+// GetSigningChain stays public.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(s *Server) {
+		s.authenticator = authenticator
+	}
+}
+
+// WithUnauthenticatedAccess opens the Issue, Renew, Amend, Revoke and List methods to any caller when no
+// authenticator is set. Without an authenticator or this option, they reject every call. Only use it when
+// the server is reachable from trusted callers alone, such as behind an authenticating proxy.
+func WithUnauthenticatedAccess() Option {
+	return func(s *Server) {
+		s.allowUnauthenticated = true
+	}
+}
+
+// Server implements licensingpb.LicensingServiceServer on top of a ContextGenerator.
+type Server struct {
+	licensingpb.UnimplementedLicensingServiceServer
+
+	generator generator.ContextGenerator
+	store     generator.IssuanceStore
+
+	authenticator        Authenticator
+	allowUnauthenticated bool
+}
+
+func NewServer(licenseGenerator generator.ContextGenerator, opts ...Option) *Server {
+	s := &Server{
+		generator: licenseGenerator,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// authenticate rejects calls that the authenticator does not accept, or every call when none is configured
+// and unauthenticated access was not allowed.
+func (s *Server) authenticate(ctx context.Context) error {
+	var err error
+	switch {
+	case s.authenticator != nil:
+		err = s.authenticator.Authenticate(ctx)
+	case s.allowUnauthenticated:
+		return nil
+	default:
+		err = denyAll.Authenticate(ctx)
+	}
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return nil
+}
+
+func (s *Server) Issue(ctx context.Context, request *licensingpb.IssueRequest) (*licensingpb.IssueResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	envelope, err := s.generator.GenerateLicenseFromRequestContext(ctx, issueRequestFromProto(request))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &licensingpb.IssueResponse{
+		Envelope: EnvelopeToProto(envelope),
+		Encoded:  envelope.EncodeBase64(),
+	}, nil
+}
+
+func (s *Server) Renew(ctx context.Context, request *licensingpb.RenewRequest) (*licensingpb.RenewResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	if request.GetExpirationTime() == nil {
+		return nil, status.Error(codes.InvalidArgument, "expiration_time is required")
+	}
+
+	envelope, err := s.generator.RenewLicenseContext(ctx, EnvelopeFromProto(request.GetEnvelope()), timeFromProto(request.GetExpirationTime()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &licensingpb.RenewResponse{
+		Envelope: EnvelopeToProto(envelope),
+		Encoded:  envelope.EncodeBase64(),
+	}, nil
+}
+
+func (s *Server) Amend(ctx context.Context, request *licensingpb.AmendRequest) (*licensingpb.AmendResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	envelope, err := s.generator.AmendLicenseContext(ctx, EnvelopeFromProto(request.GetEnvelope()), amendmentFromProto(request.GetChanges()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &licensingpb.AmendResponse{
+		Envelope: EnvelopeToProto(envelope),
+		Encoded:  envelope.EncodeBase64(),
+	}, nil
+}
+
+func (s *Server) Revoke(ctx context.Context, request *licensingpb.RevokeRequest) (*licensingpb.RevokeResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	if request.GetLicenseId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "license_id is required")
+	}
+
+	if err := s.generator.RevokeLicenseContext(ctx, request.GetLicenseId(), request.GetReason()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &licensingpb.RevokeResponse{}, nil
+}
+
+func (s *Server) List(ctx context.Context, request *licensingpb.ListRequest) (*licensingpb.ListResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	if s.store == nil {
+		return nil, status.Error(codes.Unimplemented, "issuance store is not configured")
+	}
+
+	records, err := s.store.Query(queryFromProto(request))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &licensingpb.ListResponse{
+		Records: make([]*licensingpb.IssuanceRecord, 0, len(records)),
+	}
+	for _, record := range records {
+		response.Records = append(response.Records, recordToProto(record))
+	}
+	return response, nil
+}
+
+func (s *Server) GetSigningChain(ctx context.Context, request *licensingpb.GetSigningChainRequest) (*licensingpb.GetSigningChainResponse, error) {
+	certificateChain, err := base64.StdEncoding.DecodeString(s.generator.GetPublicCertificateBase64())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &licensingpb.GetSigningChainResponse{CertificateChain: certificateChain}, nil
+}
+
+// Code maps errors returned by the generator to gRPC status codes.
+func Code(err error) codes.Code {
+	switch {
+	case errors.Is(err, generator.ErrLicenseNotFound):
+		return codes.NotFound
+	case errors.Is(err, generator.ErrRenewalRejected),
+		errors.Is(err, generator.ErrLicenseRevoked),
+		errors.Is(err, generator.ErrLicenseSuperseded):
+		return codes.FailedPrecondition
+	case errors.Is(err, generator.ErrIssuanceStoreRequired):
+		return codes.Unimplemented
+	case errors.Is(err, generator.ErrEnvelopeRequired),
+		errors.Is(err, generator.ErrEnvelopeInvalid),
+		errors.Is(err, generator.ErrEnvelopeSignatureInvalid),
+		errors.Is(err, generator.ErrLicenseRequestRequired),
+		errors.Is(err, generator.ErrInvalidLicenseRequest),
+		errors.Is(err, generator.ErrEmptyAmendment),
+		errors.Is(err, generator.ErrInvalidAmendment):
+		return codes.InvalidArgument
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+func toStatus(err error) error {
+	code := Code(err)
+	if code == codes.Internal {
+		// Do not leak internal details such as file paths or key errors
+		return status.Error(code, "internal error")
+	}
+	return status.Error(code, err.Error())
+}
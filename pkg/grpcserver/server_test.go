@@ -0,0 +1,240 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/internal/testcert"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/licensingpb"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var keyPEM, certPEM = testcert.New()
+
+func newTestHarness(t *testing.T, withStore bool, opts ...Option) *harness {
+	t.Helper()
+
+	store := generator.NewMemoryIssuanceStore()
+	generatorOpts := []generator.GeneratorOption{generator.WithRenewalPolicy(generator.RenewalPolicy{MaxExtension: 30 * 24 * time.Hour})}
+	serverOpts := opts
+	if withStore {
+		generatorOpts = append(generatorOpts, generator.WithIssuanceStore(store))
+		serverOpts = append(serverOpts, WithIssuanceStore(store))
+	}
+
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM, generatorOpts...)
+	require.NoError(t, err)
+	contextGenerator, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+
+	harness, err := newHarness(NewServer(contextGenerator, serverOpts...))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = harness.Close() })
+	return harness
+}
+
+func TestServer_LicenseLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	harness := newTestHarness(t, true, WithUnauthenticatedAccess())
+	client := harness.Client
+	expiration := time.Now().UTC().Add(48 * time.Hour)
+
+	issued, err := client.Issue(ctx, &licensingpb.IssueRequest{
+		OrganizationId:      "orgId",
+		ProductPlanUniqueId: "SKU",
+		InstanceId:          "instance-1",
+		SubscriptionId:      "subs-1",
+		ExpirationTime:      timestamppb.New(expiration),
+		Features:            []string{"sso"},
+		Limits:              map[string]int64{"seats": 10},
+	})
+	require.NoError(t, err)
+	license := issued.GetEnvelope().GetLicense()
+	assert.Equal(t, "orgId", license.GetOrganizationId())
+	assert.Equal(t, []string{"sso"}, license.GetFeatures())
+	assert.Equal(t, uint64(1), license.GetVersion())
+
+	// The proto envelope converts back into a license with a valid signature
+	licenseValidator, err := validator.NewValidatorFromBytes(certPEM)
+	require.NoError(t, err)
+	require.NoError(t, licenseValidator.ValidateLicense(EnvelopeFromProto(issued.GetEnvelope()), "orgId", "SKU", "instance-1", time.Now()))
+	require.NoError(t, licenseValidator.ValidateLicenseBase64(issued.GetEncoded(), "orgId", "SKU", "instance-1", time.Now()))
+
+	renewed, err := client.Renew(ctx, &licensingpb.RenewRequest{
+		Envelope:       issued.GetEnvelope(),
+		ExpirationTime: timestamppb.New(expiration.Add(24 * time.Hour)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, license.GetId(), renewed.GetEnvelope().GetLicense().GetId())
+	assert.Equal(t, uint64(2), renewed.GetEnvelope().GetLicense().GetVersion())
+	require.NoError(t, licenseValidator.ValidateLicense(EnvelopeFromProto(renewed.GetEnvelope()), "orgId", "SKU", "instance-1", time.Now()))
+
+	_, err = client.Renew(ctx, &licensingpb.RenewRequest{
+		Envelope:       renewed.GetEnvelope(),
+		ExpirationTime: timestamppb.New(expiration.AddDate(1, 0, 0)),
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	amended, err := client.Amend(ctx, &licensingpb.AmendRequest{
+		Envelope: renewed.GetEnvelope(),
+		Changes: &licensingpb.LicenseAmendment{
+			ProductPlanUniqueId: proto.String("SKU-PRO"),
+			Features:            &licensingpb.FeatureList{},
+		},
+	})
+	require.NoError(t, err)
+	amendedLicense := amended.GetEnvelope().GetLicense()
+	assert.Equal(t, "SKU-PRO", amendedLicense.GetProductPlanUniqueId())
+	assert.Equal(t, license.GetId(), amendedLicense.GetLineageId())
+	assert.Empty(t, amendedLicense.GetFeatures())
+	assert.Equal(t, map[string]int64{"seats": 10}, amendedLicense.GetLimits())
+
+	listed, err := client.List(ctx, &licensingpb.ListRequest{OrganizationId: "orgId"})
+	require.NoError(t, err)
+	require.Len(t, listed.GetRecords(), 1)
+	assert.Equal(t, amendedLicense.GetId(), listed.GetRecords()[0].GetLicenseId())
+	assert.Equal(t, string(generator.IssuanceEventAmended), listed.GetRecords()[0].GetEvent())
+
+	listed, err = client.List(ctx, &licensingpb.ListRequest{OrganizationId: "orgId", IncludeSuperseded: true})
+	require.NoError(t, err)
+	assert.Len(t, listed.GetRecords(), 2)
+
+	_, err = client.Revoke(ctx, &licensingpb.RevokeRequest{LicenseId: amendedLicense.GetId(), Reason: "churned"})
+	require.NoError(t, err)
+
+	listed, err = client.List(ctx, &licensingpb.ListRequest{OrganizationId: "orgId", LiveAt: timestamppb.Now()})
+	require.NoError(t, err)
+	assert.Empty(t, listed.GetRecords())
+
+	_, err = client.Revoke(ctx, &licensingpb.RevokeRequest{LicenseId: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_InvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newTestHarness(t, false, WithUnauthenticatedAccess()).Client
+
+	_, err := client.Issue(ctx, &licensingpb.IssueRequest{OrganizationId: "orgId"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Renew(ctx, &licensingpb.RenewRequest{ExpirationTime: timestamppb.Now()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Renew(ctx, &licensingpb.RenewRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Amend(ctx, &licensingpb.AmendRequest{Envelope: &licensingpb.LicenseEnvelope{License: &licensingpb.License{Id: "1"}, Signature: []byte("signature")}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	issued, err := client.Issue(ctx, &licensingpb.IssueRequest{OrganizationId: "orgId", ProductPlanUniqueId: "SKU", ExpirationTime: timestamppb.New(time.Now().Add(48 * time.Hour))})
+	require.NoError(t, err)
+	_, err = client.Amend(ctx, &licensingpb.AmendRequest{Envelope: issued.GetEnvelope(), Changes: &licensingpb.LicenseAmendment{InstanceIds: &licensingpb.InstanceIDList{InstanceIds: []string{"["}}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Revoke(ctx, &licensingpb.RevokeRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Revoke(ctx, &licensingpb.RevokeRequest{LicenseId: "license-1"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = client.List(ctx, &licensingpb.ListRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestServer_ForgedEnvelope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newTestHarness(t, true, WithUnauthenticatedAccess()).Client
+
+	license := common.NewLicense("victim", "ENTERPRISE", "", "", "", time.Now(), time.Now().Add(time.Hour))
+	forged := EnvelopeToProto(common.NewLicenseEnvelope(license, []byte("junk")))
+
+	_, err := client.Renew(ctx, &licensingpb.RenewRequest{Envelope: forged, ExpirationTime: timestamppb.New(time.Now().Add(48 * time.Hour))})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	plan := "ENTERPRISE-PLUS"
+	_, err = client.Amend(ctx, &licensingpb.AmendRequest{Envelope: forged, Changes: &licensingpb.LicenseAmendment{ProductPlanUniqueId: &plan}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_Authentication(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	request := &licensingpb.IssueRequest{OrganizationId: "orgId", ProductPlanUniqueId: "SKU", ExpirationTime: timestamppb.New(time.Now().Add(48 * time.Hour))}
+	client := newTestHarness(t, true, WithAuthenticator(NewBearerTokenAuthenticator("secret-token"))).Client
+
+	_, err := client.Issue(ctx, request)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Issue(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer wrong-token"), request)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Issue(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret-token"), request)
+	require.NoError(t, err)
+
+	// The signing chain is public
+	_, err = client.GetSigningChain(ctx, &licensingpb.GetSigningChainRequest{})
+	require.NoError(t, err)
+
+	// Without an authenticator, the licensing methods are closed unless explicitly opened
+	client = newTestHarness(t, true).Client
+	_, err = client.Issue(ctx, request)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.Renew(ctx, &licensingpb.RenewRequest{ExpirationTime: timestamppb.Now()})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.Amend(ctx, &licensingpb.AmendRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.Revoke(ctx, &licensingpb.RevokeRequest{LicenseId: "license-1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.List(ctx, &licensingpb.ListRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestServer_GetSigningChain(t *testing.T) {
+	t.Parallel()
+
+	response, err := newTestHarness(t, false).Client.GetSigningChain(context.Background(), &licensingpb.GetSigningChainRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, certPEM, response.GetCertificateChain())
+}
+
+func TestLicenseProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	license := &common.License{
+		ID:                  "license-1",
+		CreationTime:        "2025-01-01T00:00:00Z",
+		ExpirationTime:      "2026-01-01T00:00:00Z",
+		OrganizationID:      "orgId",
+		ProductPlanUniqueID: "SKU",
+		Version:             3,
+		OriginalIssueTime:   "2024-01-01T00:00:00Z",
+		LineageID:           "lineage-1",
+		SupersededLicenseID: "license-0",
+		InstanceIDs:         []string{"instance-1", "instance-dr-*"},
+		Features:            []string{"sso"},
+		Limits:              map[string]int64{"seats": 10},
+		Metadata:            map[string]string{"region": "us-east-1"},
+		NotBefore:           "2025-01-02T00:00:00Z",
+		HostFingerprint:     &common.HostFingerprint{Factors: map[string]string{"machine_id": "hash"}, MinMatches: 1},
+	}
+	assert.Equal(t, license, LicenseFromProto(LicenseToProto(license)))
+	assert.Nil(t, LicenseToProto(nil))
+	assert.Nil(t, EnvelopeFromProto(nil))
+}
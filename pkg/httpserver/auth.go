@@ -0,0 +1,55 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Authenticator decides whether a request may call the licensing endpoints.
+// A non-nil error rejects the request with 401 Unauthorized.
+type Authenticator interface {
+	Authenticate(r *http.Request) error
+}
+
+type AuthenticatorFunc func(r *http.Request) error
+
+func (f AuthenticatorFunc) Authenticate(r *http.Request) error {
+	return f(r)
+}
+
+// denyAll rejects every request, protecting handlers that have no authenticator configured.
+var denyAll = AuthenticatorFunc(func(r *http.Request) error {
+	return errors.Wrap(ErrUnauthenticated, "no authenticator is configured")
+})
+
+// NewBearerTokenAuthenticator accepts requests carrying one of the given tokens in the Authorization header.
+func NewBearerTokenAuthenticator(tokens ...string) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request) error {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			return errors.Wrap(ErrUnauthenticated, "missing bearer token")
+		}
+		for _, expected := range tokens {
+			if expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+				return nil
+			}
+		}
+		return errors.Wrap(ErrUnauthenticated, "invalid bearer token")
+	})
+}
+
+// AuthenticationMiddleware rejects requests that the authenticator does not accept.
+func AuthenticationMiddleware(authenticator Authenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := authenticator.Authenticate(r); err != nil {
+			writeError(w, http.StatusUnauthorized, "unauthenticated", "authentication required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,264 @@
+package httpserver
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/pkg/errors"
+)
+
+const defaultMaxBodySize = 1 << 20
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// OpenAPISpec returns the OpenAPI description of the licensing HTTP service.
+func OpenAPISpec() []byte {
+	return openAPISpec
+}
+
+type Option func(*Handler)
+
+// WithAuthenticator protects the issue, renew, amend and revoke endpoints.
+// The certificate and OpenAPI endpoints stay public.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(h *Handler) {
+		h.authenticator = authenticator
+	}
+}
+
+// WithUnauthenticatedAccess opens the issue, renew, amend and revoke endpoints to any caller when no
+// authenticator is set. Without an authenticator or this option, they reject every request. Only use it when
+// the handler is reachable from trusted callers alone, such as behind an authenticating proxy.
+func WithUnauthenticatedAccess() Option {
+	return func(h *Handler) {
+		h.allowUnauthenticated = true
+	}
+}
+
+func WithMaxBodySize(size int64) Option {
+	return func(h *Handler) {
+		h.maxBodySize = size
+	}
+}
+
+// Handler exposes a ContextGenerator over HTTP with JSON requests and responses.
+type Handler struct {
+	generator     generator.ContextGenerator
+	authenticator Authenticator
+	maxBodySize   int64
+	mux           *http.ServeMux
+
+	allowUnauthenticated bool
+}
+
+func NewHandler(licenseGenerator generator.ContextGenerator, opts ...Option) *Handler {
+	h := &Handler{
+		generator:   licenseGenerator,
+		maxBodySize: defaultMaxBodySize,
+		mux:         http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.Handle("POST /v1/licenses", h.protect(http.HandlerFunc(h.issue)))
+	h.mux.Handle("POST /v1/licenses/renew", h.protect(http.HandlerFunc(h.renew)))
+	h.mux.Handle("POST /v1/licenses/amend", h.protect(http.HandlerFunc(h.amend)))
+	h.mux.Handle("POST /v1/licenses/{licenseID}/revoke", h.protect(http.HandlerFunc(h.revoke)))
+	h.mux.HandleFunc("GET /v1/certificate", h.certificate)
+	h.mux.HandleFunc("GET /openapi.yaml", h.openAPI)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) protect(next http.Handler) http.Handler {
+	switch {
+	case h.authenticator != nil:
+		return AuthenticationMiddleware(h.authenticator, next)
+	case h.allowUnauthenticated:
+		return next
+	default:
+		return AuthenticationMiddleware(denyAll, next)
+	}
+}
+
+func (h *Handler) issue(w http.ResponseWriter, r *http.Request) {
+	var request IssueRequest
+	if !h.decode(w, r, &request) {
+		return
+	}
+
+	envelope, err := h.generator.GenerateLicenseFromRequestContext(r.Context(), &request)
+	if err != nil {
+		writeGeneratorError(w, err)
+		return
+	}
+	h.writeLicense(w, http.StatusCreated, envelope, request.OutputFormat)
+}
+
+func (h *Handler) renew(w http.ResponseWriter, r *http.Request) {
+	var request RenewRequest
+	if !h.decode(w, r, &request) {
+		return
+	}
+
+	envelope, ok := decodeEnvelope(w, request.License)
+	if !ok {
+		return
+	}
+	if request.ExpirationTime.IsZero() {
+		writeError(w, http.StatusBadRequest, "invalid_request", "expirationTime is required")
+		return
+	}
+
+	newEnvelope, err := h.generator.RenewLicenseContext(r.Context(), envelope, request.ExpirationTime)
+	if err != nil {
+		writeGeneratorError(w, err)
+		return
+	}
+	h.writeLicense(w, http.StatusOK, newEnvelope, generator.OutputFormatBase64)
+}
+
+func (h *Handler) amend(w http.ResponseWriter, r *http.Request) {
+	var request AmendRequest
+	if !h.decode(w, r, &request) {
+		return
+	}
+
+	envelope, ok := decodeEnvelope(w, request.License)
+	if !ok {
+		return
+	}
+
+	newEnvelope, err := h.generator.AmendLicenseContext(r.Context(), envelope, request.Changes)
+	if err != nil {
+		writeGeneratorError(w, err)
+		return
+	}
+	h.writeLicense(w, http.StatusOK, newEnvelope, generator.OutputFormatBase64)
+}
+
+func (h *Handler) revoke(w http.ResponseWriter, r *http.Request) {
+	var request RevokeRequest
+	if r.ContentLength != 0 && !h.decode(w, r, &request) {
+		return
+	}
+
+	if err := h.generator.RevokeLicenseContext(r.Context(), r.PathValue("licenseID"), request.Reason); err != nil {
+		writeGeneratorError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) certificate(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, CertificateResponse{Certificate: h.generator.GetPublicCertificateBase64()})
+}
+
+func (h *Handler) openAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(openAPISpec)
+}
+
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, "request_too_large", "request body is too large")
+			return false
+		}
+		writeError(w, http.StatusBadRequest, "invalid_request", "request body is not valid JSON")
+		return false
+	}
+	return true
+}
+
+func (h *Handler) writeLicense(w http.ResponseWriter, status int, envelope *common.LicenseEnvelope, format generator.OutputFormat) {
+	response, err := newLicenseResponse(envelope, format)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal", "failed to encode license")
+		return
+	}
+	writeJSON(w, status, response)
+}
+
+func decodeEnvelope(w http.ResponseWriter, encoded string) (*common.LicenseEnvelope, bool) {
+	if encoded == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "license is required")
+		return nil, false
+	}
+	envelope, err := common.DecodeLicenseEnvelopeFromBase64(encoded)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "license is not a base64 encoded license envelope")
+		return nil, false
+	}
+	return envelope, true
+}
+
+// StatusCode maps errors returned by the generator to HTTP status codes.
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, generator.ErrLicenseNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, generator.ErrRenewalRejected),
+		errors.Is(err, generator.ErrLicenseRevoked),
+		errors.Is(err, generator.ErrLicenseSuperseded):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, generator.ErrIssuanceStoreRequired):
+		return http.StatusNotImplemented
+	case errors.Is(err, generator.ErrEnvelopeRequired),
+		errors.Is(err, generator.ErrEnvelopeInvalid),
+		errors.Is(err, generator.ErrEnvelopeSignatureInvalid),
+		errors.Is(err, generator.ErrLicenseRequestRequired),
+		errors.Is(err, generator.ErrInvalidLicenseRequest),
+		errors.Is(err, generator.ErrEmptyAmendment),
+		errors.Is(err, generator.ErrInvalidAmendment):
+		return http.StatusBadRequest
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeGeneratorError(w http.ResponseWriter, err error) {
+	status := StatusCode(err)
+	code := "invalid_request"
+	message := err.Error()
+	switch status {
+	case http.StatusNotFound:
+		code = "not_found"
+	case http.StatusUnprocessableEntity:
+		code = "rejected"
+	case http.StatusNotImplemented:
+		code = "not_implemented"
+	case http.StatusServiceUnavailable:
+		code = "unavailable"
+	case http.StatusInternalServerError:
+		// Do not leak internal details such as file paths or key errors
+		code = "internal"
+		message = "internal error"
+	}
+	writeError(w, status, code, message)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, ErrorResponse{Error: ErrorDetail{Code: code, Message: message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,235 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, opts ...Option) *httptest.Server {
+	t.Helper()
+
+	// The signing key is the fixture of the generator package
+	licenseGenerator, err := generator.NewGeneratorFromFiles(
+		"../generator/certificate-test-tls.key",
+		"../generator/certificate-test-tls.crt",
+		generator.WithIssuanceStore(generator.NewMemoryIssuanceStore()),
+		generator.WithRenewalPolicy(generator.RenewalPolicy{MaxExtension: 30 * 24 * time.Hour}),
+	)
+	require.NoError(t, err)
+	contextGenerator, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+
+	server := httptest.NewServer(NewHandler(contextGenerator, opts...))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func post(t *testing.T, server *httptest.Server, path string, body any, headers ...string) *http.Response {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	request, err := http.NewRequest(http.MethodPost, server.URL+path, bytes.NewReader(data))
+	require.NoError(t, err)
+	for i := 0; i+1 < len(headers); i += 2 {
+		request.Header.Set(headers[i], headers[i+1])
+	}
+	response, err := server.Client().Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = response.Body.Close() })
+	return response
+}
+
+func decodeResponse[T any](t *testing.T, response *http.Response) T {
+	t.Helper()
+
+	var v T
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&v))
+	return v
+}
+
+func TestHandler_LicenseLifecycle(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, WithUnauthenticatedAccess())
+	expiration := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Second)
+
+	// Issue
+	response := post(t, server, "/v1/licenses", IssueRequest{
+		OrganizationID:      "orgId",
+		ProductPlanUniqueID: "SKU",
+		InstanceID:          "instance-1",
+		ExpirationTime:      expiration,
+		Features:            []string{"sso"},
+	})
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	issued := decodeResponse[LicenseResponse](t, response)
+	assert.NotEmpty(t, issued.LicenseID)
+	assert.Equal(t, issued.LicenseID, issued.LineageID)
+	assert.Equal(t, uint64(1), issued.Version)
+	assert.Equal(t, expiration, issued.ExpirationTime)
+
+	envelope, err := common.DecodeLicenseEnvelopeFromBase64(issued.License)
+	require.NoError(t, err)
+	assert.True(t, envelope.License.HasFeature("sso"))
+
+	// Renew
+	response = post(t, server, "/v1/licenses/renew", RenewRequest{License: issued.License, ExpirationTime: expiration.Add(24 * time.Hour)})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	renewed := decodeResponse[LicenseResponse](t, response)
+	assert.Equal(t, issued.LicenseID, renewed.LicenseID)
+	assert.Equal(t, uint64(2), renewed.Version)
+
+	// Renewal rejected by policy
+	response = post(t, server, "/v1/licenses/renew", RenewRequest{License: renewed.License, ExpirationTime: expiration.AddDate(1, 0, 0)})
+	assert.Equal(t, http.StatusUnprocessableEntity, response.StatusCode)
+	assert.Equal(t, "rejected", decodeResponse[ErrorResponse](t, response).Error.Code)
+
+	// Amend
+	plan := "SKU-PRO"
+	response = post(t, server, "/v1/licenses/amend", AmendRequest{License: renewed.License, Changes: generator.LicenseAmendment{ProductPlanUniqueID: &plan}})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	amended := decodeResponse[LicenseResponse](t, response)
+	assert.NotEqual(t, issued.LicenseID, amended.LicenseID)
+	assert.Equal(t, issued.LicenseID, amended.LineageID)
+	assert.Equal(t, uint64(3), amended.Version)
+
+	// Revoke
+	response = post(t, server, "/v1/licenses/"+amended.LicenseID+"/revoke", RevokeRequest{Reason: "downgrade"})
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+	response = post(t, server, "/v1/licenses/unknown/revoke", RevokeRequest{})
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.Equal(t, "not_found", decodeResponse[ErrorResponse](t, response).Error.Code)
+}
+
+func TestHandler_InvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, WithUnauthenticatedAccess())
+
+	response := post(t, server, "/v1/licenses", IssueRequest{OrganizationID: "orgId"})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = post(t, server, "/v1/licenses", map[string]string{"unknown": "field"})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = post(t, server, "/v1/licenses/renew", RenewRequest{License: "not-base64", ExpirationTime: time.Now()})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = post(t, server, "/v1/licenses/renew", RenewRequest{ExpirationTime: time.Now()})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = post(t, server, "/v1/licenses/amend", AmendRequest{License: (&common.LicenseEnvelope{}).EncodeBase64()})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	// Licenses not signed by the generator are neither renewed nor amended
+	forged := common.NewLicenseEnvelope(common.NewLicense("victim", "ENTERPRISE", "", "", "", time.Now(), time.Now().Add(time.Hour)), []byte("junk")).EncodeBase64()
+	response = post(t, server, "/v1/licenses/renew", RenewRequest{License: forged, ExpirationTime: time.Now().Add(48 * time.Hour)})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	plan := "ENTERPRISE-PLUS"
+	response = post(t, server, "/v1/licenses/amend", AmendRequest{License: forged, Changes: generator.LicenseAmendment{ProductPlanUniqueID: &plan}})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	// Amendments with malformed instance ID patterns are invalid requests
+	response = post(t, server, "/v1/licenses", IssueRequest{OrganizationID: "orgId", ProductPlanUniqueID: "SKU", ExpirationTime: time.Now().Add(48 * time.Hour)})
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	issued := decodeResponse[LicenseResponse](t, response)
+	response = post(t, server, "/v1/licenses/amend", AmendRequest{License: issued.License, Changes: generator.LicenseAmendment{InstanceIDs: []string{"["}}})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, "invalid_request", decodeResponse[ErrorResponse](t, response).Error.Code)
+
+	request, err := http.NewRequest(http.MethodPost, server.URL+"/v1/licenses", strings.NewReader(`{"description":"`+strings.Repeat("a", defaultMaxBodySize)+`"}`))
+	require.NoError(t, err)
+	response, err = server.Client().Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.StatusCode)
+}
+
+func TestHandler_Authentication(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, WithAuthenticator(NewBearerTokenAuthenticator("secret-token")))
+	body := IssueRequest{OrganizationID: "orgId", ExpirationTime: time.Now().Add(time.Hour)}
+
+	response := post(t, server, "/v1/licenses", body)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	assert.Equal(t, "unauthenticated", decodeResponse[ErrorResponse](t, response).Error.Code)
+
+	response = post(t, server, "/v1/licenses", body, "Authorization", "Bearer wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	response = post(t, server, "/v1/licenses", body, "Authorization", "Bearer secret-token")
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	// The certificate endpoint is public
+	response, err := server.Client().Get(server.URL + "/v1/certificate")
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	// Without an authenticator, the licensing endpoints are closed unless explicitly opened
+	server = newTestServer(t)
+	response = post(t, server, "/v1/licenses", body)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	response = post(t, server, "/v1/licenses/license-1/revoke", RevokeRequest{})
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+}
+
+func TestHandler_Certificate(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	response, err := server.Client().Get(server.URL + "/v1/certificate")
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	certificate := decodeResponse[CertificateResponse](t, response)
+	assert.NotEmpty(t, certificate.Certificate)
+}
+
+func TestHandler_OpenAPI(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	response, err := server.Client().Get(server.URL + "/openapi.yaml")
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "application/yaml", response.Header.Get("Content-Type"))
+
+	var buffer bytes.Buffer
+	_, err = buffer.ReadFrom(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, OpenAPISpec(), buffer.Bytes())
+	for _, path := range []string{"/v1/licenses:", "/v1/licenses/renew:", "/v1/licenses/amend:", "/v1/licenses/{licenseID}/revoke:", "/v1/certificate:"} {
+		assert.Contains(t, buffer.String(), path)
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, http.StatusNotFound, StatusCode(generator.ErrLicenseNotFound))
+	assert.Equal(t, http.StatusBadRequest, StatusCode(generator.ErrEnvelopeInvalid))
+	assert.Equal(t, http.StatusBadRequest, StatusCode(generator.ErrEnvelopeSignatureInvalid))
+	assert.Equal(t, http.StatusBadRequest, StatusCode(generator.ErrInvalidAmendment))
+	assert.Equal(t, http.StatusUnprocessableEntity, StatusCode(generator.ErrRenewalRejected))
+	assert.Equal(t, http.StatusUnprocessableEntity, StatusCode(generator.ErrLicenseRevoked))
+	assert.Equal(t, http.StatusNotImplemented, StatusCode(generator.ErrIssuanceStoreRequired))
+	assert.Equal(t, http.StatusInternalServerError, StatusCode(assert.AnError))
+}
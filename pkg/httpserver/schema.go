@@ -0,0 +1,71 @@
+package httpserver
+
+import (
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+)
+
+// IssueRequest is the body of POST /v1/licenses.
+type IssueRequest = generator.LicenseRequest
+
+// RenewRequest is the body of POST /v1/licenses/renew.
+type RenewRequest struct {
+	// License is the base64 encoded license envelope to renew.
+	License        string    `json:"license"`
+	ExpirationTime time.Time `json:"expirationTime"`
+}
+
+// AmendRequest is the body of POST /v1/licenses/amend.
+type AmendRequest struct {
+	// License is the base64 encoded license envelope to amend.
+	License string                     `json:"license"`
+	Changes generator.LicenseAmendment `json:"changes"`
+}
+
+// RevokeRequest is the body of POST /v1/licenses/{licenseID}/revoke.
+type RevokeRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type LicenseResponse struct {
+	LicenseID      string    `json:"licenseID"`
+	LineageID      string    `json:"lineageID"`
+	Version        uint64    `json:"version"`
+	ExpirationTime time.Time `json:"expirationTime"`
+	// License is the encoded license envelope, base64 unless another output format was requested.
+	License string `json:"license"`
+}
+
+type CertificateResponse struct {
+	// Certificate is the base64 encoded PEM certificate chain used to validate licenses.
+	Certificate string `json:"certificate"`
+}
+
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func newLicenseResponse(envelope *common.LicenseEnvelope, format generator.OutputFormat) (*LicenseResponse, error) {
+	encoded, err := format.Encode(envelope)
+	if err != nil {
+		return nil, err
+	}
+	expirationTime, err := envelope.License.GetExpirationTime()
+	if err != nil {
+		return nil, err
+	}
+	return &LicenseResponse{
+		LicenseID:      envelope.License.ID,
+		LineageID:      envelope.License.GetLineageID(),
+		Version:        envelope.License.Version,
+		ExpirationTime: expirationTime,
+		License:        encoded,
+	}, nil
+}
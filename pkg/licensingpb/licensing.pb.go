@@ -0,0 +1,1737 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: omnistrate/licensing/v1/licensing.proto
+
+package licensingpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// License mirrors common.License. Times are kept as the RFC 3339 strings that were signed so that a
+// license can be converted back without invalidating its signature.
+type License struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreationTime          string                 `protobuf:"bytes,2,opt,name=creation_time,json=creationTime,proto3" json:"creation_time,omitempty"`
+	ExpirationTime        string                 `protobuf:"bytes,3,opt,name=expiration_time,json=expirationTime,proto3" json:"expiration_time,omitempty"`
+	Description           string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	InstanceId            string                 `protobuf:"bytes,5,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	SubscriptionId        string                 `protobuf:"bytes,6,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	ProductPlanUniqueId   string                 `protobuf:"bytes,7,opt,name=product_plan_unique_id,json=productPlanUniqueId,proto3" json:"product_plan_unique_id,omitempty"`
+	OrganizationId        string                 `protobuf:"bytes,8,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Version               uint64                 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	OriginalIssueTime     string                 `protobuf:"bytes,10,opt,name=original_issue_time,json=originalIssueTime,proto3" json:"original_issue_time,omitempty"`
+	PreviousSignatureHash string                 `protobuf:"bytes,11,opt,name=previous_signature_hash,json=previousSignatureHash,proto3" json:"previous_signature_hash,omitempty"`
+	LineageId             string                 `protobuf:"bytes,12,opt,name=lineage_id,json=lineageId,proto3" json:"lineage_id,omitempty"`
+	SupersededLicenseId   string                 `protobuf:"bytes,13,opt,name=superseded_license_id,json=supersededLicenseId,proto3" json:"superseded_license_id,omitempty"`
+	Features              []string               `protobuf:"bytes,14,rep,name=features,proto3" json:"features,omitempty"`
+	Limits                map[string]int64       `protobuf:"bytes,15,rep,name=limits,proto3" json:"limits,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	NotBefore             string                 `protobuf:"bytes,16,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	Metadata              map[string]string      `protobuf:"bytes,17,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	InstanceIds           []string               `protobuf:"bytes,18,rep,name=instance_ids,json=instanceIds,proto3" json:"instance_ids,omitempty"`
+	HostFingerprint       *HostFingerprint       `protobuf:"bytes,19,opt,name=host_fingerprint,json=hostFingerprint,proto3" json:"host_fingerprint,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *License) Reset() {
+	*x = License{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *License) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*License) ProtoMessage() {}
+
+func (x *License) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use License.ProtoReflect.Descriptor instead.
+func (*License) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *License) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *License) GetCreationTime() string {
+	if x != nil {
+		return x.CreationTime
+	}
+	return ""
+}
+
+func (x *License) GetExpirationTime() string {
+	if x != nil {
+		return x.ExpirationTime
+	}
+	return ""
+}
+
+func (x *License) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *License) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *License) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *License) GetProductPlanUniqueId() string {
+	if x != nil {
+		return x.ProductPlanUniqueId
+	}
+	return ""
+}
+
+func (x *License) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *License) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *License) GetOriginalIssueTime() string {
+	if x != nil {
+		return x.OriginalIssueTime
+	}
+	return ""
+}
+
+func (x *License) GetPreviousSignatureHash() string {
+	if x != nil {
+		return x.PreviousSignatureHash
+	}
+	return ""
+}
+
+func (x *License) GetLineageId() string {
+	if x != nil {
+		return x.LineageId
+	}
+	return ""
+}
+
+func (x *License) GetSupersededLicenseId() string {
+	if x != nil {
+		return x.SupersededLicenseId
+	}
+	return ""
+}
+
+func (x *License) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *License) GetLimits() map[string]int64 {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *License) GetNotBefore() string {
+	if x != nil {
+		return x.NotBefore
+	}
+	return ""
+}
+
+func (x *License) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *License) GetInstanceIds() []string {
+	if x != nil {
+		return x.InstanceIds
+	}
+	return nil
+}
+
+func (x *License) GetHostFingerprint() *HostFingerprint {
+	if x != nil {
+		return x.HostFingerprint
+	}
+	return nil
+}
+
+// HostFingerprint mirrors common.HostFingerprint.
+type HostFingerprint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Factors       map[string]string      `protobuf:"bytes,1,rep,name=factors,proto3" json:"factors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	MinMatches    int32                  `protobuf:"varint,2,opt,name=min_matches,json=minMatches,proto3" json:"min_matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostFingerprint) Reset() {
+	*x = HostFingerprint{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostFingerprint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostFingerprint) ProtoMessage() {}
+
+func (x *HostFingerprint) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostFingerprint.ProtoReflect.Descriptor instead.
+func (*HostFingerprint) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HostFingerprint) GetFactors() map[string]string {
+	if x != nil {
+		return x.Factors
+	}
+	return nil
+}
+
+func (x *HostFingerprint) GetMinMatches() int32 {
+	if x != nil {
+		return x.MinMatches
+	}
+	return 0
+}
+
+// LicenseEnvelope mirrors common.LicenseEnvelope.
+type LicenseEnvelope struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	License   *License               `protobuf:"bytes,1,opt,name=license,proto3" json:"license,omitempty"`
+	Signature []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// RFC 3161 timestamp token over the signature, when the license was timestamped.
+	Timestamp     []byte `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LicenseEnvelope) Reset() {
+	*x = LicenseEnvelope{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LicenseEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LicenseEnvelope) ProtoMessage() {}
+
+func (x *LicenseEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LicenseEnvelope.ProtoReflect.Descriptor instead.
+func (*LicenseEnvelope) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LicenseEnvelope) GetLicense() *License {
+	if x != nil {
+		return x.License
+	}
+	return nil
+}
+
+func (x *LicenseEnvelope) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *LicenseEnvelope) GetTimestamp() []byte {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type IssueRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId      string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	ProductPlanUniqueId string                 `protobuf:"bytes,2,opt,name=product_plan_unique_id,json=productPlanUniqueId,proto3" json:"product_plan_unique_id,omitempty"`
+	InstanceId          string                 `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	SubscriptionId      string                 `protobuf:"bytes,4,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	Description         string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	ExpirationTime      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expiration_time,json=expirationTime,proto3" json:"expiration_time,omitempty"`
+	IssueTime           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=issue_time,json=issueTime,proto3" json:"issue_time,omitempty"`
+	NotBefore           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	Features            []string               `protobuf:"bytes,9,rep,name=features,proto3" json:"features,omitempty"`
+	Limits              map[string]int64       `protobuf:"bytes,10,rep,name=limits,proto3" json:"limits,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Metadata            map[string]string      `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	InstanceIds         []string               `protobuf:"bytes,12,rep,name=instance_ids,json=instanceIds,proto3" json:"instance_ids,omitempty"`
+	HostFingerprint     *HostFingerprint       `protobuf:"bytes,13,opt,name=host_fingerprint,json=hostFingerprint,proto3" json:"host_fingerprint,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *IssueRequest) Reset() {
+	*x = IssueRequest{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueRequest) ProtoMessage() {}
+
+func (x *IssueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueRequest.ProtoReflect.Descriptor instead.
+func (*IssueRequest) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IssueRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *IssueRequest) GetProductPlanUniqueId() string {
+	if x != nil {
+		return x.ProductPlanUniqueId
+	}
+	return ""
+}
+
+func (x *IssueRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *IssueRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *IssueRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *IssueRequest) GetExpirationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationTime
+	}
+	return nil
+}
+
+func (x *IssueRequest) GetIssueTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssueTime
+	}
+	return nil
+}
+
+func (x *IssueRequest) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *IssueRequest) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *IssueRequest) GetLimits() map[string]int64 {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *IssueRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *IssueRequest) GetInstanceIds() []string {
+	if x != nil {
+		return x.InstanceIds
+	}
+	return nil
+}
+
+func (x *IssueRequest) GetHostFingerprint() *HostFingerprint {
+	if x != nil {
+		return x.HostFingerprint
+	}
+	return nil
+}
+
+type IssueResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Envelope *LicenseEnvelope       `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	// Base64 encoded license envelope, as written to license files.
+	Encoded       string `protobuf:"bytes,2,opt,name=encoded,proto3" json:"encoded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueResponse) Reset() {
+	*x = IssueResponse{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueResponse) ProtoMessage() {}
+
+func (x *IssueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueResponse.ProtoReflect.Descriptor instead.
+func (*IssueResponse) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *IssueResponse) GetEnvelope() *LicenseEnvelope {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *IssueResponse) GetEncoded() string {
+	if x != nil {
+		return x.Encoded
+	}
+	return ""
+}
+
+type RenewRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Envelope       *LicenseEnvelope       `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	ExpirationTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiration_time,json=expirationTime,proto3" json:"expiration_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RenewRequest) Reset() {
+	*x = RenewRequest{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewRequest) ProtoMessage() {}
+
+func (x *RenewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewRequest.ProtoReflect.Descriptor instead.
+func (*RenewRequest) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RenewRequest) GetEnvelope() *LicenseEnvelope {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *RenewRequest) GetExpirationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationTime
+	}
+	return nil
+}
+
+type RenewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Envelope      *LicenseEnvelope       `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	Encoded       string                 `protobuf:"bytes,2,opt,name=encoded,proto3" json:"encoded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewResponse) Reset() {
+	*x = RenewResponse{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewResponse) ProtoMessage() {}
+
+func (x *RenewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewResponse.ProtoReflect.Descriptor instead.
+func (*RenewResponse) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RenewResponse) GetEnvelope() *LicenseEnvelope {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *RenewResponse) GetEncoded() string {
+	if x != nil {
+		return x.Encoded
+	}
+	return ""
+}
+
+type FeatureList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Features      []string               `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureList) Reset() {
+	*x = FeatureList{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureList) ProtoMessage() {}
+
+func (x *FeatureList) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureList.ProtoReflect.Descriptor instead.
+func (*FeatureList) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FeatureList) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type LimitMap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        map[string]int64       `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LimitMap) Reset() {
+	*x = LimitMap{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LimitMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimitMap) ProtoMessage() {}
+
+func (x *LimitMap) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LimitMap.ProtoReflect.Descriptor instead.
+func (*LimitMap) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LimitMap) GetLimits() map[string]int64 {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type InstanceIDList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceIds   []string               `protobuf:"bytes,1,rep,name=instance_ids,json=instanceIds,proto3" json:"instance_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceIDList) Reset() {
+	*x = InstanceIDList{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceIDList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceIDList) ProtoMessage() {}
+
+func (x *InstanceIDList) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceIDList.ProtoReflect.Descriptor instead.
+func (*InstanceIDList) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InstanceIDList) GetInstanceIds() []string {
+	if x != nil {
+		return x.InstanceIds
+	}
+	return nil
+}
+
+// LicenseAmendment mirrors generator.LicenseAmendment. Unset fields are left unchanged.
+type LicenseAmendment struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ProductPlanUniqueId *string                `protobuf:"bytes,1,opt,name=product_plan_unique_id,json=productPlanUniqueId,proto3,oneof" json:"product_plan_unique_id,omitempty"`
+	InstanceId          *string                `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3,oneof" json:"instance_id,omitempty"`
+	Description         *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	ExpirationTime      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiration_time,json=expirationTime,proto3" json:"expiration_time,omitempty"`
+	Features            *FeatureList           `protobuf:"bytes,5,opt,name=features,proto3" json:"features,omitempty"`
+	Limits              *LimitMap              `protobuf:"bytes,6,opt,name=limits,proto3" json:"limits,omitempty"`
+	InstanceIds         *InstanceIDList        `protobuf:"bytes,7,opt,name=instance_ids,json=instanceIds,proto3" json:"instance_ids,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *LicenseAmendment) Reset() {
+	*x = LicenseAmendment{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LicenseAmendment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LicenseAmendment) ProtoMessage() {}
+
+func (x *LicenseAmendment) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LicenseAmendment.ProtoReflect.Descriptor instead.
+func (*LicenseAmendment) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LicenseAmendment) GetProductPlanUniqueId() string {
+	if x != nil && x.ProductPlanUniqueId != nil {
+		return *x.ProductPlanUniqueId
+	}
+	return ""
+}
+
+func (x *LicenseAmendment) GetInstanceId() string {
+	if x != nil && x.InstanceId != nil {
+		return *x.InstanceId
+	}
+	return ""
+}
+
+func (x *LicenseAmendment) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *LicenseAmendment) GetExpirationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationTime
+	}
+	return nil
+}
+
+func (x *LicenseAmendment) GetFeatures() *FeatureList {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *LicenseAmendment) GetLimits() *LimitMap {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *LicenseAmendment) GetInstanceIds() *InstanceIDList {
+	if x != nil {
+		return x.InstanceIds
+	}
+	return nil
+}
+
+type AmendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Envelope      *LicenseEnvelope       `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	Changes       *LicenseAmendment      `protobuf:"bytes,2,opt,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmendRequest) Reset() {
+	*x = AmendRequest{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendRequest) ProtoMessage() {}
+
+func (x *AmendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendRequest.ProtoReflect.Descriptor instead.
+func (*AmendRequest) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AmendRequest) GetEnvelope() *LicenseEnvelope {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *AmendRequest) GetChanges() *LicenseAmendment {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type AmendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Envelope      *LicenseEnvelope       `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	Encoded       string                 `protobuf:"bytes,2,opt,name=encoded,proto3" json:"encoded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmendResponse) Reset() {
+	*x = AmendResponse{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendResponse) ProtoMessage() {}
+
+func (x *AmendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendResponse.ProtoReflect.Descriptor instead.
+func (*AmendResponse) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AmendResponse) GetEnvelope() *LicenseEnvelope {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *AmendResponse) GetEncoded() string {
+	if x != nil {
+		return x.Encoded
+	}
+	return ""
+}
+
+type RevokeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LicenseId     string                 `protobuf:"bytes,1,opt,name=license_id,json=licenseId,proto3" json:"license_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRequest) Reset() {
+	*x = RevokeRequest{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRequest) ProtoMessage() {}
+
+func (x *RevokeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRequest.ProtoReflect.Descriptor instead.
+func (*RevokeRequest) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RevokeRequest) GetLicenseId() string {
+	if x != nil {
+		return x.LicenseId
+	}
+	return ""
+}
+
+func (x *RevokeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RevokeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeResponse) Reset() {
+	*x = RevokeResponse{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeResponse) ProtoMessage() {}
+
+func (x *RevokeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeResponse.ProtoReflect.Descriptor instead.
+func (*RevokeResponse) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{14}
+}
+
+// ListRequest mirrors generator.IssuanceQuery.
+type ListRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId      string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	SubscriptionId      string                 `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	InstanceId          string                 `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	ProductPlanUniqueId string                 `protobuf:"bytes,4,opt,name=product_plan_unique_id,json=productPlanUniqueId,proto3" json:"product_plan_unique_id,omitempty"`
+	LineageId           string                 `protobuf:"bytes,5,opt,name=lineage_id,json=lineageId,proto3" json:"lineage_id,omitempty"`
+	ExpiresAfter        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_after,json=expiresAfter,proto3" json:"expires_after,omitempty"`
+	ExpiresBefore       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	LiveAt              *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=live_at,json=liveAt,proto3" json:"live_at,omitempty"`
+	IncludeRevoked      bool                   `protobuf:"varint,9,opt,name=include_revoked,json=includeRevoked,proto3" json:"include_revoked,omitempty"`
+	IncludeSuperseded   bool                   `protobuf:"varint,10,opt,name=include_superseded,json=includeSuperseded,proto3" json:"include_superseded,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *ListRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *ListRequest) GetProductPlanUniqueId() string {
+	if x != nil {
+		return x.ProductPlanUniqueId
+	}
+	return ""
+}
+
+func (x *ListRequest) GetLineageId() string {
+	if x != nil {
+		return x.LineageId
+	}
+	return ""
+}
+
+func (x *ListRequest) GetExpiresAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAfter
+	}
+	return nil
+}
+
+func (x *ListRequest) GetExpiresBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresBefore
+	}
+	return nil
+}
+
+func (x *ListRequest) GetLiveAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LiveAt
+	}
+	return nil
+}
+
+func (x *ListRequest) GetIncludeRevoked() bool {
+	if x != nil {
+		return x.IncludeRevoked
+	}
+	return false
+}
+
+func (x *ListRequest) GetIncludeSuperseded() bool {
+	if x != nil {
+		return x.IncludeSuperseded
+	}
+	return false
+}
+
+// IssuanceRecord mirrors generator.IssuanceRecord.
+type IssuanceRecord struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	LicenseId           string                 `protobuf:"bytes,1,opt,name=license_id,json=licenseId,proto3" json:"license_id,omitempty"`
+	LineageId           string                 `protobuf:"bytes,2,opt,name=lineage_id,json=lineageId,proto3" json:"lineage_id,omitempty"`
+	OrganizationId      string                 `protobuf:"bytes,3,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	ProductPlanUniqueId string                 `protobuf:"bytes,4,opt,name=product_plan_unique_id,json=productPlanUniqueId,proto3" json:"product_plan_unique_id,omitempty"`
+	InstanceId          string                 `protobuf:"bytes,5,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	SubscriptionId      string                 `protobuf:"bytes,6,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	Version             uint64                 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	Event               string                 `protobuf:"bytes,8,opt,name=event,proto3" json:"event,omitempty"`
+	CreationTime        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=creation_time,json=creationTime,proto3" json:"creation_time,omitempty"`
+	ExpirationTime      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expiration_time,json=expirationTime,proto3" json:"expiration_time,omitempty"`
+	RecordedAt          *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	SupersededLicenseId string                 `protobuf:"bytes,12,opt,name=superseded_license_id,json=supersededLicenseId,proto3" json:"superseded_license_id,omitempty"`
+	SupersededBy        string                 `protobuf:"bytes,13,opt,name=superseded_by,json=supersededBy,proto3" json:"superseded_by,omitempty"`
+	RevokedAt           *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	RevocationReason    string                 `protobuf:"bytes,15,opt,name=revocation_reason,json=revocationReason,proto3" json:"revocation_reason,omitempty"`
+	Encoded             string                 `protobuf:"bytes,16,opt,name=encoded,proto3" json:"encoded,omitempty"`
+	InstanceIds         []string               `protobuf:"bytes,17,rep,name=instance_ids,json=instanceIds,proto3" json:"instance_ids,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *IssuanceRecord) Reset() {
+	*x = IssuanceRecord{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssuanceRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssuanceRecord) ProtoMessage() {}
+
+func (x *IssuanceRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssuanceRecord.ProtoReflect.Descriptor instead.
+func (*IssuanceRecord) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *IssuanceRecord) GetLicenseId() string {
+	if x != nil {
+		return x.LicenseId
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetLineageId() string {
+	if x != nil {
+		return x.LineageId
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetProductPlanUniqueId() string {
+	if x != nil {
+		return x.ProductPlanUniqueId
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *IssuanceRecord) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetCreationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreationTime
+	}
+	return nil
+}
+
+func (x *IssuanceRecord) GetExpirationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationTime
+	}
+	return nil
+}
+
+func (x *IssuanceRecord) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+func (x *IssuanceRecord) GetSupersededLicenseId() string {
+	if x != nil {
+		return x.SupersededLicenseId
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetSupersededBy() string {
+	if x != nil {
+		return x.SupersededBy
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *IssuanceRecord) GetRevocationReason() string {
+	if x != nil {
+		return x.RevocationReason
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetEncoded() string {
+	if x != nil {
+		return x.Encoded
+	}
+	return ""
+}
+
+func (x *IssuanceRecord) GetInstanceIds() []string {
+	if x != nil {
+		return x.InstanceIds
+	}
+	return nil
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*IssuanceRecord      `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListResponse) GetRecords() []*IssuanceRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type GetSigningChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSigningChainRequest) Reset() {
+	*x = GetSigningChainRequest{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSigningChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningChainRequest) ProtoMessage() {}
+
+func (x *GetSigningChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningChainRequest.ProtoReflect.Descriptor instead.
+func (*GetSigningChainRequest) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{18}
+}
+
+type GetSigningChainResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// PEM encoded certificate chain used to validate licenses.
+	CertificateChain []byte `protobuf:"bytes,1,opt,name=certificate_chain,json=certificateChain,proto3" json:"certificate_chain,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetSigningChainResponse) Reset() {
+	*x = GetSigningChainResponse{}
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSigningChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningChainResponse) ProtoMessage() {}
+
+func (x *GetSigningChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_omnistrate_licensing_v1_licensing_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningChainResponse.ProtoReflect.Descriptor instead.
+func (*GetSigningChainResponse) Descriptor() ([]byte, []int) {
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetSigningChainResponse) GetCertificateChain() []byte {
+	if x != nil {
+		return x.CertificateChain
+	}
+	return nil
+}
+
+var File_omnistrate_licensing_v1_licensing_proto protoreflect.FileDescriptor
+
+const file_omnistrate_licensing_v1_licensing_proto_rawDesc = "" +
+	"\n" +
+	"'omnistrate/licensing/v1/licensing.proto\x12\x17omnistrate.licensing.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc3\a\n" +
+	"\aLicense\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rcreation_time\x18\x02 \x01(\tR\fcreationTime\x12'\n" +
+	"\x0fexpiration_time\x18\x03 \x01(\tR\x0eexpirationTime\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vinstance_id\x18\x05 \x01(\tR\n" +
+	"instanceId\x12'\n" +
+	"\x0fsubscription_id\x18\x06 \x01(\tR\x0esubscriptionId\x123\n" +
+	"\x16product_plan_unique_id\x18\a \x01(\tR\x13productPlanUniqueId\x12'\n" +
+	"\x0forganization_id\x18\b \x01(\tR\x0eorganizationId\x12\x18\n" +
+	"\aversion\x18\t \x01(\x04R\aversion\x12.\n" +
+	"\x13original_issue_time\x18\n" +
+	" \x01(\tR\x11originalIssueTime\x126\n" +
+	"\x17previous_signature_hash\x18\v \x01(\tR\x15previousSignatureHash\x12\x1d\n" +
+	"\n" +
+	"lineage_id\x18\f \x01(\tR\tlineageId\x122\n" +
+	"\x15superseded_license_id\x18\r \x01(\tR\x13supersededLicenseId\x12\x1a\n" +
+	"\bfeatures\x18\x0e \x03(\tR\bfeatures\x12D\n" +
+	"\x06limits\x18\x0f \x03(\v2,.omnistrate.licensing.v1.License.LimitsEntryR\x06limits\x12\x1d\n" +
+	"\n" +
+	"not_before\x18\x10 \x01(\tR\tnotBefore\x12J\n" +
+	"\bmetadata\x18\x11 \x03(\v2..omnistrate.licensing.v1.License.MetadataEntryR\bmetadata\x12!\n" +
+	"\finstance_ids\x18\x12 \x03(\tR\vinstanceIds\x12S\n" +
+	"\x10host_fingerprint\x18\x13 \x01(\v2(.omnistrate.licensing.v1.HostFingerprintR\x0fhostFingerprint\x1a9\n" +
+	"\vLimitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbf\x01\n" +
+	"\x0fHostFingerprint\x12O\n" +
+	"\afactors\x18\x01 \x03(\v25.omnistrate.licensing.v1.HostFingerprint.FactorsEntryR\afactors\x12\x1f\n" +
+	"\vmin_matches\x18\x02 \x01(\x05R\n" +
+	"minMatches\x1a:\n" +
+	"\fFactorsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x89\x01\n" +
+	"\x0fLicenseEnvelope\x12:\n" +
+	"\alicense\x18\x01 \x01(\v2 .omnistrate.licensing.v1.LicenseR\alicense\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\fR\tsignature\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\fR\ttimestamp\"\xbb\x06\n" +
+	"\fIssueRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x123\n" +
+	"\x16product_plan_unique_id\x18\x02 \x01(\tR\x13productPlanUniqueId\x12\x1f\n" +
+	"\vinstance_id\x18\x03 \x01(\tR\n" +
+	"instanceId\x12'\n" +
+	"\x0fsubscription_id\x18\x04 \x01(\tR\x0esubscriptionId\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12C\n" +
+	"\x0fexpiration_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0eexpirationTime\x129\n" +
+	"\n" +
+	"issue_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tissueTime\x129\n" +
+	"\n" +
+	"not_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x12\x1a\n" +
+	"\bfeatures\x18\t \x03(\tR\bfeatures\x12I\n" +
+	"\x06limits\x18\n" +
+	" \x03(\v21.omnistrate.licensing.v1.IssueRequest.LimitsEntryR\x06limits\x12O\n" +
+	"\bmetadata\x18\v \x03(\v23.omnistrate.licensing.v1.IssueRequest.MetadataEntryR\bmetadata\x12!\n" +
+	"\finstance_ids\x18\f \x03(\tR\vinstanceIds\x12S\n" +
+	"\x10host_fingerprint\x18\r \x01(\v2(.omnistrate.licensing.v1.HostFingerprintR\x0fhostFingerprint\x1a9\n" +
+	"\vLimitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"o\n" +
+	"\rIssueResponse\x12D\n" +
+	"\benvelope\x18\x01 \x01(\v2(.omnistrate.licensing.v1.LicenseEnvelopeR\benvelope\x12\x18\n" +
+	"\aencoded\x18\x02 \x01(\tR\aencoded\"\x99\x01\n" +
+	"\fRenewRequest\x12D\n" +
+	"\benvelope\x18\x01 \x01(\v2(.omnistrate.licensing.v1.LicenseEnvelopeR\benvelope\x12C\n" +
+	"\x0fexpiration_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x0eexpirationTime\"o\n" +
+	"\rRenewResponse\x12D\n" +
+	"\benvelope\x18\x01 \x01(\v2(.omnistrate.licensing.v1.LicenseEnvelopeR\benvelope\x12\x18\n" +
+	"\aencoded\x18\x02 \x01(\tR\aencoded\")\n" +
+	"\vFeatureList\x12\x1a\n" +
+	"\bfeatures\x18\x01 \x03(\tR\bfeatures\"\x8c\x01\n" +
+	"\bLimitMap\x12E\n" +
+	"\x06limits\x18\x01 \x03(\v2-.omnistrate.licensing.v1.LimitMap.LimitsEntryR\x06limits\x1a9\n" +
+	"\vLimitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"3\n" +
+	"\x0eInstanceIDList\x12!\n" +
+	"\finstance_ids\x18\x01 \x03(\tR\vinstanceIds\"\xe2\x03\n" +
+	"\x10LicenseAmendment\x128\n" +
+	"\x16product_plan_unique_id\x18\x01 \x01(\tH\x00R\x13productPlanUniqueId\x88\x01\x01\x12$\n" +
+	"\vinstance_id\x18\x02 \x01(\tH\x01R\n" +
+	"instanceId\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x02R\vdescription\x88\x01\x01\x12C\n" +
+	"\x0fexpiration_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0eexpirationTime\x12@\n" +
+	"\bfeatures\x18\x05 \x01(\v2$.omnistrate.licensing.v1.FeatureListR\bfeatures\x129\n" +
+	"\x06limits\x18\x06 \x01(\v2!.omnistrate.licensing.v1.LimitMapR\x06limits\x12J\n" +
+	"\finstance_ids\x18\a \x01(\v2'.omnistrate.licensing.v1.InstanceIDListR\vinstanceIdsB\x19\n" +
+	"\x17_product_plan_unique_idB\x0e\n" +
+	"\f_instance_idB\x0e\n" +
+	"\f_description\"\x99\x01\n" +
+	"\fAmendRequest\x12D\n" +
+	"\benvelope\x18\x01 \x01(\v2(.omnistrate.licensing.v1.LicenseEnvelopeR\benvelope\x12C\n" +
+	"\achanges\x18\x02 \x01(\v2).omnistrate.licensing.v1.LicenseAmendmentR\achanges\"o\n" +
+	"\rAmendResponse\x12D\n" +
+	"\benvelope\x18\x01 \x01(\v2(.omnistrate.licensing.v1.LicenseEnvelopeR\benvelope\x12\x18\n" +
+	"\aencoded\x18\x02 \x01(\tR\aencoded\"F\n" +
+	"\rRevokeRequest\x12\x1d\n" +
+	"\n" +
+	"license_id\x18\x01 \x01(\tR\tlicenseId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x10\n" +
+	"\x0eRevokeResponse\"\xe5\x03\n" +
+	"\vListRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\tR\x0esubscriptionId\x12\x1f\n" +
+	"\vinstance_id\x18\x03 \x01(\tR\n" +
+	"instanceId\x123\n" +
+	"\x16product_plan_unique_id\x18\x04 \x01(\tR\x13productPlanUniqueId\x12\x1d\n" +
+	"\n" +
+	"lineage_id\x18\x05 \x01(\tR\tlineageId\x12?\n" +
+	"\rexpires_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fexpiresAfter\x12A\n" +
+	"\x0eexpires_before\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rexpiresBefore\x123\n" +
+	"\alive_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x06liveAt\x12'\n" +
+	"\x0finclude_revoked\x18\t \x01(\bR\x0eincludeRevoked\x12-\n" +
+	"\x12include_superseded\x18\n" +
+	" \x01(\bR\x11includeSuperseded\"\xe7\x05\n" +
+	"\x0eIssuanceRecord\x12\x1d\n" +
+	"\n" +
+	"license_id\x18\x01 \x01(\tR\tlicenseId\x12\x1d\n" +
+	"\n" +
+	"lineage_id\x18\x02 \x01(\tR\tlineageId\x12'\n" +
+	"\x0forganization_id\x18\x03 \x01(\tR\x0eorganizationId\x123\n" +
+	"\x16product_plan_unique_id\x18\x04 \x01(\tR\x13productPlanUniqueId\x12\x1f\n" +
+	"\vinstance_id\x18\x05 \x01(\tR\n" +
+	"instanceId\x12'\n" +
+	"\x0fsubscription_id\x18\x06 \x01(\tR\x0esubscriptionId\x12\x18\n" +
+	"\aversion\x18\a \x01(\x04R\aversion\x12\x14\n" +
+	"\x05event\x18\b \x01(\tR\x05event\x12?\n" +
+	"\rcreation_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fcreationTime\x12C\n" +
+	"\x0fexpiration_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0eexpirationTime\x12;\n" +
+	"\vrecorded_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recordedAt\x122\n" +
+	"\x15superseded_license_id\x18\f \x01(\tR\x13supersededLicenseId\x12#\n" +
+	"\rsuperseded_by\x18\r \x01(\tR\fsupersededBy\x129\n" +
+	"\n" +
+	"revoked_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12+\n" +
+	"\x11revocation_reason\x18\x0f \x01(\tR\x10revocationReason\x12\x18\n" +
+	"\aencoded\x18\x10 \x01(\tR\aencoded\x12!\n" +
+	"\finstance_ids\x18\x11 \x03(\tR\vinstanceIds\"Q\n" +
+	"\fListResponse\x12A\n" +
+	"\arecords\x18\x01 \x03(\v2'.omnistrate.licensing.v1.IssuanceRecordR\arecords\"\x18\n" +
+	"\x16GetSigningChainRequest\"F\n" +
+	"\x17GetSigningChainResponse\x12+\n" +
+	"\x11certificate_chain\x18\x01 \x01(\fR\x10certificateChain2\xc0\x04\n" +
+	"\x10LicensingService\x12V\n" +
+	"\x05Issue\x12%.omnistrate.licensing.v1.IssueRequest\x1a&.omnistrate.licensing.v1.IssueResponse\x12V\n" +
+	"\x05Renew\x12%.omnistrate.licensing.v1.RenewRequest\x1a&.omnistrate.licensing.v1.RenewResponse\x12V\n" +
+	"\x05Amend\x12%.omnistrate.licensing.v1.AmendRequest\x1a&.omnistrate.licensing.v1.AmendResponse\x12Y\n" +
+	"\x06Revoke\x12&.omnistrate.licensing.v1.RevokeRequest\x1a'.omnistrate.licensing.v1.RevokeResponse\x12S\n" +
+	"\x04List\x12$.omnistrate.licensing.v1.ListRequest\x1a%.omnistrate.licensing.v1.ListResponse\x12t\n" +
+	"\x0fGetSigningChain\x12/.omnistrate.licensing.v1.GetSigningChainRequest\x1a0.omnistrate.licensing.v1.GetSigningChainResponseBSZQgithub.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/licensingpb;licensingpbb\x06proto3"
+
+var (
+	file_omnistrate_licensing_v1_licensing_proto_rawDescOnce sync.Once
+	file_omnistrate_licensing_v1_licensing_proto_rawDescData []byte
+)
+
+func file_omnistrate_licensing_v1_licensing_proto_rawDescGZIP() []byte {
+	file_omnistrate_licensing_v1_licensing_proto_rawDescOnce.Do(func() {
+		file_omnistrate_licensing_v1_licensing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_omnistrate_licensing_v1_licensing_proto_rawDesc), len(file_omnistrate_licensing_v1_licensing_proto_rawDesc)))
+	})
+	return file_omnistrate_licensing_v1_licensing_proto_rawDescData
+}
+
+var file_omnistrate_licensing_v1_licensing_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_omnistrate_licensing_v1_licensing_proto_goTypes = []any{
+	(*License)(nil),                 // 0: omnistrate.licensing.v1.License
+	(*HostFingerprint)(nil),         // 1: omnistrate.licensing.v1.HostFingerprint
+	(*LicenseEnvelope)(nil),         // 2: omnistrate.licensing.v1.LicenseEnvelope
+	(*IssueRequest)(nil),            // 3: omnistrate.licensing.v1.IssueRequest
+	(*IssueResponse)(nil),           // 4: omnistrate.licensing.v1.IssueResponse
+	(*RenewRequest)(nil),            // 5: omnistrate.licensing.v1.RenewRequest
+	(*RenewResponse)(nil),           // 6: omnistrate.licensing.v1.RenewResponse
+	(*FeatureList)(nil),             // 7: omnistrate.licensing.v1.FeatureList
+	(*LimitMap)(nil),                // 8: omnistrate.licensing.v1.LimitMap
+	(*InstanceIDList)(nil),          // 9: omnistrate.licensing.v1.InstanceIDList
+	(*LicenseAmendment)(nil),        // 10: omnistrate.licensing.v1.LicenseAmendment
+	(*AmendRequest)(nil),            // 11: omnistrate.licensing.v1.AmendRequest
+	(*AmendResponse)(nil),           // 12: omnistrate.licensing.v1.AmendResponse
+	(*RevokeRequest)(nil),           // 13: omnistrate.licensing.v1.RevokeRequest
+	(*RevokeResponse)(nil),          // 14: omnistrate.licensing.v1.RevokeResponse
+	(*ListRequest)(nil),             // 15: omnistrate.licensing.v1.ListRequest
+	(*IssuanceRecord)(nil),          // 16: omnistrate.licensing.v1.IssuanceRecord
+	(*ListResponse)(nil),            // 17: omnistrate.licensing.v1.ListResponse
+	(*GetSigningChainRequest)(nil),  // 18: omnistrate.licensing.v1.GetSigningChainRequest
+	(*GetSigningChainResponse)(nil), // 19: omnistrate.licensing.v1.GetSigningChainResponse
+	nil,                             // 20: omnistrate.licensing.v1.License.LimitsEntry
+	nil,                             // 21: omnistrate.licensing.v1.License.MetadataEntry
+	nil,                             // 22: omnistrate.licensing.v1.HostFingerprint.FactorsEntry
+	nil,                             // 23: omnistrate.licensing.v1.IssueRequest.LimitsEntry
+	nil,                             // 24: omnistrate.licensing.v1.IssueRequest.MetadataEntry
+	nil,                             // 25: omnistrate.licensing.v1.LimitMap.LimitsEntry
+	(*timestamppb.Timestamp)(nil),   // 26: google.protobuf.Timestamp
+}
+var file_omnistrate_licensing_v1_licensing_proto_depIdxs = []int32{
+	20, // 0: omnistrate.licensing.v1.License.limits:type_name -> omnistrate.licensing.v1.License.LimitsEntry
+	21, // 1: omnistrate.licensing.v1.License.metadata:type_name -> omnistrate.licensing.v1.License.MetadataEntry
+	1,  // 2: omnistrate.licensing.v1.License.host_fingerprint:type_name -> omnistrate.licensing.v1.HostFingerprint
+	22, // 3: omnistrate.licensing.v1.HostFingerprint.factors:type_name -> omnistrate.licensing.v1.HostFingerprint.FactorsEntry
+	0,  // 4: omnistrate.licensing.v1.LicenseEnvelope.license:type_name -> omnistrate.licensing.v1.License
+	26, // 5: omnistrate.licensing.v1.IssueRequest.expiration_time:type_name -> google.protobuf.Timestamp
+	26, // 6: omnistrate.licensing.v1.IssueRequest.issue_time:type_name -> google.protobuf.Timestamp
+	26, // 7: omnistrate.licensing.v1.IssueRequest.not_before:type_name -> google.protobuf.Timestamp
+	23, // 8: omnistrate.licensing.v1.IssueRequest.limits:type_name -> omnistrate.licensing.v1.IssueRequest.LimitsEntry
+	24, // 9: omnistrate.licensing.v1.IssueRequest.metadata:type_name -> omnistrate.licensing.v1.IssueRequest.MetadataEntry
+	1,  // 10: omnistrate.licensing.v1.IssueRequest.host_fingerprint:type_name -> omnistrate.licensing.v1.HostFingerprint
+	2,  // 11: omnistrate.licensing.v1.IssueResponse.envelope:type_name -> omnistrate.licensing.v1.LicenseEnvelope
+	2,  // 12: omnistrate.licensing.v1.RenewRequest.envelope:type_name -> omnistrate.licensing.v1.LicenseEnvelope
+	26, // 13: omnistrate.licensing.v1.RenewRequest.expiration_time:type_name -> google.protobuf.Timestamp
+	2,  // 14: omnistrate.licensing.v1.RenewResponse.envelope:type_name -> omnistrate.licensing.v1.LicenseEnvelope
+	25, // 15: omnistrate.licensing.v1.LimitMap.limits:type_name -> omnistrate.licensing.v1.LimitMap.LimitsEntry
+	26, // 16: omnistrate.licensing.v1.LicenseAmendment.expiration_time:type_name -> google.protobuf.Timestamp
+	7,  // 17: omnistrate.licensing.v1.LicenseAmendment.features:type_name -> omnistrate.licensing.v1.FeatureList
+	8,  // 18: omnistrate.licensing.v1.LicenseAmendment.limits:type_name -> omnistrate.licensing.v1.LimitMap
+	9,  // 19: omnistrate.licensing.v1.LicenseAmendment.instance_ids:type_name -> omnistrate.licensing.v1.InstanceIDList
+	2,  // 20: omnistrate.licensing.v1.AmendRequest.envelope:type_name -> omnistrate.licensing.v1.LicenseEnvelope
+	10, // 21: omnistrate.licensing.v1.AmendRequest.changes:type_name -> omnistrate.licensing.v1.LicenseAmendment
+	2,  // 22: omnistrate.licensing.v1.AmendResponse.envelope:type_name -> omnistrate.licensing.v1.LicenseEnvelope
+	26, // 23: omnistrate.licensing.v1.ListRequest.expires_after:type_name -> google.protobuf.Timestamp
+	26, // 24: omnistrate.licensing.v1.ListRequest.expires_before:type_name -> google.protobuf.Timestamp
+	26, // 25: omnistrate.licensing.v1.ListRequest.live_at:type_name -> google.protobuf.Timestamp
+	26, // 26: omnistrate.licensing.v1.IssuanceRecord.creation_time:type_name -> google.protobuf.Timestamp
+	26, // 27: omnistrate.licensing.v1.IssuanceRecord.expiration_time:type_name -> google.protobuf.Timestamp
+	26, // 28: omnistrate.licensing.v1.IssuanceRecord.recorded_at:type_name -> google.protobuf.Timestamp
+	26, // 29: omnistrate.licensing.v1.IssuanceRecord.revoked_at:type_name -> google.protobuf.Timestamp
+	16, // 30: omnistrate.licensing.v1.ListResponse.records:type_name -> omnistrate.licensing.v1.IssuanceRecord
+	3,  // 31: omnistrate.licensing.v1.LicensingService.Issue:input_type -> omnistrate.licensing.v1.IssueRequest
+	5,  // 32: omnistrate.licensing.v1.LicensingService.Renew:input_type -> omnistrate.licensing.v1.RenewRequest
+	11, // 33: omnistrate.licensing.v1.LicensingService.Amend:input_type -> omnistrate.licensing.v1.AmendRequest
+	13, // 34: omnistrate.licensing.v1.LicensingService.Revoke:input_type -> omnistrate.licensing.v1.RevokeRequest
+	15, // 35: omnistrate.licensing.v1.LicensingService.List:input_type -> omnistrate.licensing.v1.ListRequest
+	18, // 36: omnistrate.licensing.v1.LicensingService.GetSigningChain:input_type -> omnistrate.licensing.v1.GetSigningChainRequest
+	4,  // 37: omnistrate.licensing.v1.LicensingService.Issue:output_type -> omnistrate.licensing.v1.IssueResponse
+	6,  // 38: omnistrate.licensing.v1.LicensingService.Renew:output_type -> omnistrate.licensing.v1.RenewResponse
+	12, // 39: omnistrate.licensing.v1.LicensingService.Amend:output_type -> omnistrate.licensing.v1.AmendResponse
+	14, // 40: omnistrate.licensing.v1.LicensingService.Revoke:output_type -> omnistrate.licensing.v1.RevokeResponse
+	17, // 41: omnistrate.licensing.v1.LicensingService.List:output_type -> omnistrate.licensing.v1.ListResponse
+	19, // 42: omnistrate.licensing.v1.LicensingService.GetSigningChain:output_type -> omnistrate.licensing.v1.GetSigningChainResponse
+	37, // [37:43] is the sub-list for method output_type
+	31, // [31:37] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
+}
+
+func init() { file_omnistrate_licensing_v1_licensing_proto_init() }
+func file_omnistrate_licensing_v1_licensing_proto_init() {
+	if File_omnistrate_licensing_v1_licensing_proto != nil {
+		return
+	}
+	file_omnistrate_licensing_v1_licensing_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_omnistrate_licensing_v1_licensing_proto_rawDesc), len(file_omnistrate_licensing_v1_licensing_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_omnistrate_licensing_v1_licensing_proto_goTypes,
+		DependencyIndexes: file_omnistrate_licensing_v1_licensing_proto_depIdxs,
+		MessageInfos:      file_omnistrate_licensing_v1_licensing_proto_msgTypes,
+	}.Build()
+	File_omnistrate_licensing_v1_licensing_proto = out.File
+	file_omnistrate_licensing_v1_licensing_proto_goTypes = nil
+	file_omnistrate_licensing_v1_licensing_proto_depIdxs = nil
+}
@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: omnistrate/licensing/v1/licensing.proto
+
+package licensingpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LicensingService_Issue_FullMethodName           = "/omnistrate.licensing.v1.LicensingService/Issue"
+	LicensingService_Renew_FullMethodName           = "/omnistrate.licensing.v1.LicensingService/Renew"
+	LicensingService_Amend_FullMethodName           = "/omnistrate.licensing.v1.LicensingService/Amend"
+	LicensingService_Revoke_FullMethodName          = "/omnistrate.licensing.v1.LicensingService/Revoke"
+	LicensingService_List_FullMethodName            = "/omnistrate.licensing.v1.LicensingService/List"
+	LicensingService_GetSigningChain_FullMethodName = "/omnistrate.licensing.v1.LicensingService/GetSigningChain"
+)
+
+// LicensingServiceClient is the client API for LicensingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LicensingService issues and manages licenses signed by the licensing SDK generator.
+type LicensingServiceClient interface {
+	Issue(ctx context.Context, in *IssueRequest, opts ...grpc.CallOption) (*IssueResponse, error)
+	Renew(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*RenewResponse, error)
+	Amend(ctx context.Context, in *AmendRequest, opts ...grpc.CallOption) (*AmendResponse, error)
+	Revoke(ctx context.Context, in *RevokeRequest, opts ...grpc.CallOption) (*RevokeResponse, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	GetSigningChain(ctx context.Context, in *GetSigningChainRequest, opts ...grpc.CallOption) (*GetSigningChainResponse, error)
+}
+
+type licensingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLicensingServiceClient(cc grpc.ClientConnInterface) LicensingServiceClient {
+	return &licensingServiceClient{cc}
+}
+
+func (c *licensingServiceClient) Issue(ctx context.Context, in *IssueRequest, opts ...grpc.CallOption) (*IssueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueResponse)
+	err := c.cc.Invoke(ctx, LicensingService_Issue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licensingServiceClient) Renew(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*RenewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenewResponse)
+	err := c.cc.Invoke(ctx, LicensingService_Renew_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licensingServiceClient) Amend(ctx context.Context, in *AmendRequest, opts ...grpc.CallOption) (*AmendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AmendResponse)
+	err := c.cc.Invoke(ctx, LicensingService_Amend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licensingServiceClient) Revoke(ctx context.Context, in *RevokeRequest, opts ...grpc.CallOption) (*RevokeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeResponse)
+	err := c.cc.Invoke(ctx, LicensingService_Revoke_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licensingServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, LicensingService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licensingServiceClient) GetSigningChain(ctx context.Context, in *GetSigningChainRequest, opts ...grpc.CallOption) (*GetSigningChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSigningChainResponse)
+	err := c.cc.Invoke(ctx, LicensingService_GetSigningChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LicensingServiceServer is the server API for LicensingService service.
+// All implementations must embed UnimplementedLicensingServiceServer
+// for forward compatibility.
+//
+// LicensingService issues and manages licenses signed by the licensing SDK generator.
+type LicensingServiceServer interface {
+	Issue(context.Context, *IssueRequest) (*IssueResponse, error)
+	Renew(context.Context, *RenewRequest) (*RenewResponse, error)
+	Amend(context.Context, *AmendRequest) (*AmendResponse, error)
+	Revoke(context.Context, *RevokeRequest) (*RevokeResponse, error)
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	GetSigningChain(context.Context, *GetSigningChainRequest) (*GetSigningChainResponse, error)
+	mustEmbedUnimplementedLicensingServiceServer()
+}
+
+// UnimplementedLicensingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLicensingServiceServer struct{}
+
+func (UnimplementedLicensingServiceServer) Issue(context.Context, *IssueRequest) (*IssueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Issue not implemented")
+}
+func (UnimplementedLicensingServiceServer) Renew(context.Context, *RenewRequest) (*RenewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Renew not implemented")
+}
+func (UnimplementedLicensingServiceServer) Amend(context.Context, *AmendRequest) (*AmendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Amend not implemented")
+}
+func (UnimplementedLicensingServiceServer) Revoke(context.Context, *RevokeRequest) (*RevokeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Revoke not implemented")
+}
+func (UnimplementedLicensingServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedLicensingServiceServer) GetSigningChain(context.Context, *GetSigningChainRequest) (*GetSigningChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSigningChain not implemented")
+}
+func (UnimplementedLicensingServiceServer) mustEmbedUnimplementedLicensingServiceServer() {}
+func (UnimplementedLicensingServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeLicensingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LicensingServiceServer will
+// result in compilation errors.
+type UnsafeLicensingServiceServer interface {
+	mustEmbedUnimplementedLicensingServiceServer()
+}
+
+func RegisterLicensingServiceServer(s grpc.ServiceRegistrar, srv LicensingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLicensingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LicensingService_ServiceDesc, srv)
+}
+
+func _LicensingService_Issue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicensingServiceServer).Issue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LicensingService_Issue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicensingServiceServer).Issue(ctx, req.(*IssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicensingService_Renew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicensingServiceServer).Renew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LicensingService_Renew_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicensingServiceServer).Renew(ctx, req.(*RenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicensingService_Amend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicensingServiceServer).Amend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LicensingService_Amend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicensingServiceServer).Amend(ctx, req.(*AmendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicensingService_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicensingServiceServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LicensingService_Revoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicensingServiceServer).Revoke(ctx, req.(*RevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicensingService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicensingServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LicensingService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicensingServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicensingService_GetSigningChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSigningChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicensingServiceServer).GetSigningChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LicensingService_GetSigningChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicensingServiceServer).GetSigningChain(ctx, req.(*GetSigningChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LicensingService_ServiceDesc is the grpc.ServiceDesc for LicensingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LicensingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "omnistrate.licensing.v1.LicensingService",
+	HandlerType: (*LicensingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Issue",
+			Handler:    _LicensingService_Issue_Handler,
+		},
+		{
+			MethodName: "Renew",
+			Handler:    _LicensingService_Renew_Handler,
+		},
+		{
+			MethodName: "Amend",
+			Handler:    _LicensingService_Amend_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _LicensingService_Revoke_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _LicensingService_List_Handler,
+		},
+		{
+			MethodName: "GetSigningChain",
+			Handler:    _LicensingService_GetSigningChain_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "omnistrate/licensing/v1/licensing.proto",
+}
@@ -0,0 +1,125 @@
+// Package telemetry reports license validation and generation as OpenTelemetry spans and metrics.
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/telemetry"
+
+const (
+	AttributeOperation     = attribute.Key("license.operation")
+	AttributeOutcome       = attribute.Key("license.outcome")
+	AttributeLicenseID     = attribute.Key("license.id")
+	AttributeOrganization  = attribute.Key("license.organization_id")
+	AttributePlan          = attribute.Key("license.plan_id")
+	AttributeFailureReason = attribute.Key("license.failure_reason")
+)
+
+const (
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+)
+
+type Option func(*Instrumentation)
+
+// WithTracerProvider emits spans through the provider. Defaults to the global provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(i *Instrumentation) {
+		i.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider records metrics through the provider. Defaults to the global provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(i *Instrumentation) {
+		i.meterProvider = provider
+	}
+}
+
+// Instrumentation implements common.Instrumentation with OpenTelemetry. Spans and metrics carry license
+// identifiers and failure reasons, never license contents, signatures or error messages.
+type Instrumentation struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+
+	tracer     trace.Tracer
+	operations metric.Int64Counter
+	duration   metric.Float64Histogram
+}
+
+func New(opts ...Option) (*Instrumentation, error) {
+	i := &Instrumentation{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	i.tracer = i.tracerProvider.Tracer(instrumentationName)
+	meter := i.meterProvider.Meter(instrumentationName)
+
+	var err error
+	i.operations, err = meter.Int64Counter("license.operations",
+		metric.WithDescription("License operations by outcome and failure reason."),
+		metric.WithUnit("{operation}"))
+	if err != nil {
+		return nil, err
+	}
+	i.duration, err = meter.Float64Histogram("license.operation.duration",
+		metric.WithDescription("Duration of license operations."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (i *Instrumentation) Start(ctx context.Context, operation common.Operation) (context.Context, common.OperationEnd) {
+	start := time.Now()
+	ctx, span := i.tracer.Start(ctx, string(operation), trace.WithAttributes(AttributeOperation.String(string(operation))))
+
+	return ctx, func(info common.OperationInfo, err error) {
+		defer span.End()
+
+		var spanAttrs []attribute.KeyValue
+		if info.LicenseID != "" {
+			spanAttrs = append(spanAttrs, AttributeLicenseID.String(info.LicenseID))
+		}
+		if info.OrganizationID != "" {
+			spanAttrs = append(spanAttrs, AttributeOrganization.String(info.OrganizationID))
+		}
+		if info.ProductPlanUniqueID != "" {
+			spanAttrs = append(spanAttrs, AttributePlan.String(info.ProductPlanUniqueID))
+		}
+
+		// License and organization IDs are left out of metrics to bound their cardinality
+		metricAttrs := []attribute.KeyValue{AttributeOperation.String(string(operation))}
+		if err != nil {
+			reason := info.Reason
+			if reason == "" {
+				reason = "error"
+			}
+			spanAttrs = append(spanAttrs, AttributeFailureReason.String(reason))
+			metricAttrs = append(metricAttrs, AttributeOutcome.String(outcomeFailure), AttributeFailureReason.String(reason))
+			// The error message may describe the license, so only the reason is reported
+			span.SetStatus(codes.Error, reason)
+		} else {
+			metricAttrs = append(metricAttrs, AttributeOutcome.String(outcomeSuccess))
+		}
+		span.SetAttributes(spanAttrs...)
+
+		attrs := metric.WithAttributes(metricAttrs...)
+		i.operations.Add(ctx, 1, attrs)
+		i.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+	}
+}
@@ -0,0 +1,207 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/internal/testcert"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var keyPEM, certPEM = testcert.New()
+
+func newTestInstrumentation(t *testing.T) (*Instrumentation, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	instrumentation, err := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	require.NoError(t, err)
+	return instrumentation, exporter, reader
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]string {
+	attrs := map[attribute.Key]string{}
+	for _, attr := range span.Attributes {
+		attrs[attr.Key] = attr.Value.Emit()
+	}
+	return attrs
+}
+
+// operationCounts returns the license.operations counter by operation, outcome and failure reason.
+func operationCounts(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+
+	counts := map[string]int64{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "license.operations" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				operation, _ := point.Attributes.Value(AttributeOperation)
+				outcome, _ := point.Attributes.Value(AttributeOutcome)
+				reason, _ := point.Attributes.Value(AttributeFailureReason)
+				assert.False(t, point.Attributes.HasValue(AttributeLicenseID))
+				counts[fmt.Sprintf("%s/%s/%s", operation.Emit(), outcome.Emit(), reason.Emit())] = point.Value
+			}
+		}
+	}
+	return counts
+}
+
+func TestInstrumentation_Generator(t *testing.T) {
+	t.Parallel()
+
+	instrumentation, exporter, reader := newTestInstrumentation(t)
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM, generator.WithInstrumentation(instrumentation))
+	require.NoError(t, err)
+
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+	renewed, err := manager.RenewLicense(envelope, time.Now().Add(48*time.Hour))
+	require.NoError(t, err)
+	_, err = manager.RenewLicense(nil, time.Now().Add(48*time.Hour))
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, string(common.OperationGenerateLicense), spans[0].Name)
+	assert.Equal(t, map[attribute.Key]string{
+		AttributeOperation:    string(common.OperationGenerateLicense),
+		AttributeLicenseID:    envelope.License.ID,
+		AttributeOrganization: "orgId",
+		AttributePlan:         "SKU",
+	}, spanAttributes(spans[0]))
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	assert.Equal(t, string(common.OperationRenewLicense), spans[1].Name)
+	assert.Equal(t, renewed.License.ID, spanAttributes(spans[1])[AttributeLicenseID])
+
+	assert.Equal(t, codes.Error, spans[2].Status.Code)
+	assert.Equal(t, "error", spanAttributes(spans[2])[AttributeFailureReason])
+
+	assert.Equal(t, map[string]int64{
+		"license.generate/success/":   1,
+		"license.renew/success/":      1,
+		"license.renew/failure/error": 1,
+	}, operationCounts(t, reader))
+}
+
+func TestInstrumentation_Validator(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(time.Hour))
+	require.NoError(t, err)
+
+	instrumentation, exporter, reader := newTestInstrumentation(t)
+	licenseValidator, err := validator.NewValidatorFromBytes(certPEM, validator.WithInstrumentation(instrumentation))
+	require.NoError(t, err)
+
+	require.NoError(t, licenseValidator.ValidateLicense(envelope, "orgId", "SKU", "instance-1", now))
+	require.ErrorIs(t, licenseValidator.ValidateLicenseBase64(envelope.EncodeBase64(), "orgId", "SKU", "instance-1", now.Add(2*time.Hour)), validator.ErrLicenseExpired)
+	require.Error(t, licenseValidator.ValidateLicenseString("not a license", "orgId", "SKU", "instance-1", now))
+	// The test certificate has expired
+	require.ErrorIs(t, licenseValidator.ValidateCertificate("licensing.omnistrate.cloud", now), validator.ErrInvalidCertificate)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+	assert.Equal(t, envelope.License.ID, spanAttributes(spans[0])[AttributeLicenseID])
+	assert.Equal(t, "expired", spanAttributes(spans[1])[AttributeFailureReason])
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "expired", spans[1].Status.Description)
+	assert.Equal(t, "invalid", spanAttributes(spans[2])[AttributeFailureReason])
+	assert.Equal(t, string(common.OperationValidateCertificate), spans[3].Name)
+	assert.Equal(t, "invalid_certificate", spanAttributes(spans[3])[AttributeFailureReason])
+
+	// Spans never carry the license contents or signature
+	signature := base64.StdEncoding.EncodeToString(envelope.Signature)
+	for _, span := range spans {
+		for _, value := range spanAttributes(span) {
+			assert.NotContains(t, value, signature)
+			assert.NotContains(t, value, "product a")
+		}
+		assert.Empty(t, span.Events)
+	}
+
+	assert.Equal(t, map[string]int64{
+		"license.validate/success/":                                1,
+		"license.validate/failure/expired":                         1,
+		"license.validate/failure/invalid":                         1,
+		"license.validate_certificate/failure/invalid_certificate": 1,
+	}, operationCounts(t, reader))
+}
+
+func TestInstrumentation_ForgedLicense(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(time.Hour))
+	require.NoError(t, err)
+	envelope.License.ID = "forged-license"
+	envelope.License.OrganizationID = "forged-org"
+
+	instrumentation, exporter, _ := newTestInstrumentation(t)
+	licenseValidator, err := validator.NewValidatorFromBytes(certPEM, validator.WithInstrumentation(instrumentation))
+	require.NoError(t, err)
+	require.ErrorIs(t, licenseValidator.ValidateLicense(envelope, "forged-org", "SKU", "instance-1", now), validator.ErrInvalidSignature)
+
+	// The claims of a license whose signature does not verify are not reported
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	attributes := spanAttributes(spans[0])
+	assert.Equal(t, "invalid_signature", attributes[AttributeFailureReason])
+	for _, value := range attributes {
+		assert.NotContains(t, value, "forged")
+	}
+}
+
+func TestInstrumentation_ValidateLicenseWithOptions(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	instrumentation, exporter, _ := newTestInstrumentation(t)
+	err = validator.ValidateLicenseWithOptions(validator.ValidationOptions{
+		SkipCertificateValidation: true,
+		LicenseSource:             validator.NewBytesSource([]byte(envelope.String())),
+		CertSource:                validator.NewBytesSource(certPEM),
+		OrganizationID:            "orgId",
+		ProductPlanUniqueID:       "SKU",
+		InstanceID:                "instance-1",
+		Instrumentation:           instrumentation,
+	})
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, string(common.OperationValidateLicense), spans[0].Name)
+}
@@ -0,0 +1,98 @@
+package timestamp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/digitorus/timestamp"
+	"github.com/pkg/errors"
+)
+
+// localPolicy is the placeholder TSA policy of the test tokens.
+var localPolicy = asn1.ObjectIdentifier{1, 2, 3, 4, 1}
+
+// localAuthority is a test timestamp authority issuing tokens in-process with its own certificate and key.
+// It is an http.Handler speaking the RFC 3161 HTTP protocol, so it can also be served with httptest.
+type localAuthority struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+	now  func() time.Time
+}
+
+// newLocalAuthority signs tokens with a certificate that must have the time stamping extended key usage.
+func newLocalAuthority(cert *x509.Certificate, key crypto.Signer) *localAuthority {
+	return &localAuthority{
+		cert: cert,
+		key:  key,
+		now:  time.Now,
+	}
+}
+
+// WithClock returns a copy of the authority that reads the time from now.
+func (a *localAuthority) WithClock(now func() time.Time) *localAuthority {
+	clone := *a
+	clone.now = now
+	return &clone
+}
+
+// Timestamp returns a DER encoded timestamp token over data.
+func (a *localAuthority) Timestamp(ctx context.Context, data []byte) ([]byte, error) {
+	query, err := timestamp.CreateRequest(bytes.NewReader(data), &timestamp.RequestOptions{
+		Hash:         crypto.SHA256,
+		Certificates: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reply, err := a.respond(query)
+	if err != nil {
+		return nil, err
+	}
+	token, err := timestamp.ParseResponse(reply)
+	if err != nil {
+		return nil, err
+	}
+	return token.RawToken, nil
+}
+
+func (a *localAuthority) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	query, err := io.ReadAll(io.LimitReader(r.Body, maxResponseSize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	reply, err := a.respond(query)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", timestampReplyContentType)
+	_, _ = w.Write(reply)
+}
+
+func (a *localAuthority) respond(query []byte) ([]byte, error) {
+	request, err := timestamp.ParseRequest(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timestamp request")
+	}
+
+	token := &timestamp.Timestamp{
+		HashAlgorithm:     request.HashAlgorithm,
+		HashedMessage:     request.HashedMessage,
+		Time:              a.now().UTC(),
+		Nonce:             request.Nonce,
+		Policy:            localPolicy,
+		AddTSACertificate: true,
+	}
+	return token.CreateResponseWithOpts(a.cert, a.key, crypto.SHA256)
+}
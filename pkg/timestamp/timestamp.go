@@ -0,0 +1,168 @@
+// Package timestamp obtains and verifies RFC 3161 timestamp tokens over license signatures.
+package timestamp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/digitorus/pkcs7"
+	"github.com/digitorus/timestamp"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxResponseSize = 1 << 20
+
+	timestampQueryContentType = "application/timestamp-query"
+	timestampReplyContentType = "application/timestamp-reply"
+)
+
+// HTTPAuthority requests timestamp tokens from a timestamp authority over HTTP.
+type HTTPAuthority struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPAuthority uses the given client, or http.DefaultClient when nil. Plain HTTP is acceptable since
+// timestamp tokens are signed and verified independently of the transport.
+func NewHTTPAuthority(url string, client *http.Client) *HTTPAuthority {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPAuthority{url: url, client: client}
+}
+
+// Timestamp returns a DER encoded timestamp token over data.
+func (a *HTTPAuthority) Timestamp(ctx context.Context, data []byte) ([]byte, error) {
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, err
+	}
+	query, err := timestamp.CreateRequest(bytes.NewReader(data), &timestamp.RequestOptions{
+		Hash:         crypto.SHA256,
+		Certificates: true,
+		Nonce:        nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", timestampQueryContentType)
+	request.Header.Set("Accept", timestampReplyContentType)
+
+	response, err := a.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from timestamp authority", response.StatusCode)
+	}
+	reply, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) > maxResponseSize {
+		return nil, fmt.Errorf("timestamp response exceeds %d bytes", maxResponseSize)
+	}
+
+	token, err := timestamp.ParseResponse(reply)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timestamp response")
+	}
+	if token.Nonce == nil || token.Nonce.Cmp(nonce) != 0 {
+		return nil, fmt.Errorf("timestamp response does not match the request nonce")
+	}
+	if err = checkImprint(token, data); err != nil {
+		return nil, err
+	}
+	return token.RawToken, nil
+}
+
+// Verifier verifies timestamp tokens and the certificate chain of the timestamp authority that signed them.
+type Verifier struct {
+	roots *x509.CertPool
+}
+
+// NewVerifier trusts timestamp authorities chaining to the given roots.
+func NewVerifier(roots *x509.CertPool) *Verifier {
+	return &Verifier{roots: roots}
+}
+
+// NewVerifierFromPEM trusts timestamp authorities chaining to the PEM encoded root certificates.
+func NewVerifierFromPEM(rootsPEM []byte) (*Verifier, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootsPEM) {
+		return nil, fmt.Errorf("no certificates found in PEM data")
+	}
+	return NewVerifier(roots), nil
+}
+
+// Verify checks the token was signed by a trusted timestamp authority, valid at the attested time, over data.
+// It returns the attested time.
+func (v *Verifier) Verify(token, data []byte) (time.Time, error) {
+	if v.roots == nil {
+		return time.Time{}, fmt.Errorf("no trusted timestamp authority roots configured")
+	}
+
+	parsed, err := timestamp.Parse(token)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to parse timestamp token")
+	}
+	if len(parsed.Certificates) == 0 {
+		return time.Time{}, fmt.Errorf("timestamp token does not include the authority certificate")
+	}
+	if err = checkImprint(parsed, data); err != nil {
+		return time.Time{}, err
+	}
+
+	p7, err := pkcs7.Parse(token)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to parse timestamp token")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range p7.Certificates {
+		intermediates.AddCert(cert)
+	}
+	err = p7.VerifyWithOpts(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   parsed.Time,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	})
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to verify timestamp authority")
+	}
+
+	return parsed.Time, nil
+}
+
+// checkImprint checks the token was issued over data with a strong hash.
+func checkImprint(token *timestamp.Timestamp, data []byte) error {
+	switch token.HashAlgorithm {
+	case crypto.SHA256, crypto.SHA384, crypto.SHA512:
+	default:
+		return fmt.Errorf("timestamp token uses an unsupported hash algorithm")
+	}
+
+	hash := token.HashAlgorithm.New()
+	hash.Write(data)
+	if !hmac.Equal(hash.Sum(nil), token.HashedMessage) {
+		return fmt.Errorf("timestamp token does not cover the signature")
+	}
+	return nil
+}
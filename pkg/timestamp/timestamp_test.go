@@ -0,0 +1,166 @@
+package timestamp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/internal/testcert"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var keyPEM, certPEM = testcert.New()
+
+// newTestAuthority creates a timestamp authority with a certificate issued by a new root.
+func newTestAuthority(t *testing.T) (*localAuthority, *x509.CertPool) {
+	t.Helper()
+
+	now := time.Now()
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test TSA Root"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, &rootKey.PublicKey, rootKey)
+	require.NoError(t, err)
+	root, err := x509.ParseCertificate(rootDER)
+	require.NoError(t, err)
+
+	tsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tsaTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test TSA"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}
+	tsaDER, err := x509.CreateCertificate(rand.Reader, tsaTemplate, root, &tsaKey.PublicKey, rootKey)
+	require.NoError(t, err)
+	tsaCert, err := x509.ParseCertificate(tsaDER)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	return newLocalAuthority(tsaCert, tsaKey), roots
+}
+
+func TestHTTPAuthority(t *testing.T) {
+	t.Parallel()
+
+	authority, roots := newTestAuthority(t)
+	server := httptest.NewServer(authority)
+	defer server.Close()
+
+	data := []byte("signature")
+	token, err := NewHTTPAuthority(server.URL, server.Client()).Timestamp(context.Background(), data)
+	require.NoError(t, err)
+
+	signedAt, err := NewVerifier(roots).Verify(token, data)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), signedAt, time.Minute)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	_, err = NewHTTPAuthority(failing.URL, failing.Client()).Timestamp(context.Background(), data)
+	require.ErrorContains(t, err, "unexpected status 503")
+}
+
+func TestVerifier(t *testing.T) {
+	t.Parallel()
+
+	authority, roots := newTestAuthority(t)
+	data := []byte("signature")
+	token, err := authority.Timestamp(context.Background(), data)
+	require.NoError(t, err)
+
+	_, err = NewVerifier(roots).Verify(token, data)
+	require.NoError(t, err)
+
+	_, err = NewVerifier(roots).Verify(token, []byte("other signature"))
+	require.ErrorContains(t, err, "does not cover the signature")
+
+	// Tokens from an authority chaining to another root are rejected
+	_, otherRoots := newTestAuthority(t)
+	_, err = NewVerifier(otherRoots).Verify(token, data)
+	require.ErrorContains(t, err, "failed to verify timestamp authority")
+
+	// The authority certificate must be valid at the attested time
+	late := authority.WithClock(func() time.Time { return time.Now().Add(48 * time.Hour) })
+	lateToken, err := late.Timestamp(context.Background(), data)
+	require.NoError(t, err)
+	_, err = NewVerifier(roots).Verify(lateToken, data)
+	require.ErrorContains(t, err, "failed to verify timestamp authority")
+
+	tampered := append([]byte{}, token...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = NewVerifier(roots).Verify(tampered, data)
+	require.Error(t, err)
+
+	_, err = NewVerifier(roots).Verify([]byte("not a token"), data)
+	require.Error(t, err)
+
+	_, err = NewVerifierFromPEM([]byte("not a certificate"))
+	require.Error(t, err)
+}
+
+func TestTimestampedLicense(t *testing.T) {
+	t.Parallel()
+
+	authority, roots := newTestAuthority(t)
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM, generator.WithTimestampAuthority(authority))
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NotEmpty(t, envelope.Timestamp)
+
+	options := validator.ValidationOptions{
+		SkipCertificateValidation: true,
+		LicenseSource:             validator.NewBytesSource([]byte(envelope.String())),
+		CertSource:                validator.NewBytesSource(certPEM),
+		OrganizationID:            "orgId",
+		ProductPlanUniqueID:       "SKU",
+		InstanceID:                "instance-1",
+		TimestampVerifier:         NewVerifier(roots),
+		RequireTimestamp:          true,
+	}
+	require.NoError(t, validator.ValidateLicenseWithOptions(options))
+
+	_, otherRoots := newTestAuthority(t)
+	options.TimestampVerifier = NewVerifier(otherRoots)
+	result := validator.ValidateLicenseWithResult(options)
+	require.ErrorIs(t, result.Err, validator.ErrInvalidTimestamp)
+	assert.Equal(t, validator.ReasonInvalidTimestamp, result.Reason)
+
+	// Licenses without a token are only accepted when timestamps are optional
+	untimestamped, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err = untimestamped.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	options.LicenseSource = validator.NewBytesSource([]byte(envelope.String()))
+	options.TimestampVerifier = NewVerifier(roots)
+	require.ErrorIs(t, validator.ValidateLicenseWithOptions(options), validator.ErrTimestampRequired)
+
+	options.RequireTimestamp = false
+	require.NoError(t, validator.ValidateLicenseWithOptions(options))
+}
@@ -1,12 +1,21 @@
 package validator
 
+import "time"
+
 const (
 	licenseCertPathEnv   = "SERVICE_PLAN_SUBSCRIPTION_LICENSE_CERT_PATH"
 	licenseFilePathEnv   = "SERVICE_PLAN_SUBSCRIPTION_LICENSE_FILE_PATH"
 	licenseInstanceIDEnv = "INSTANCE_ID"
 
+	licenseEnforcementModeEnv = "SERVICE_PLAN_SUBSCRIPTION_LICENSE_ENFORCEMENT_MODE"
+
 	defaultValidatorCertPath    = "/var/subscription/license.crt"
 	defaultValidatorLicensePath = "/var/subscription/license.lic"
 
+	defaultNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
 	signingCertificateValidDnsName = "licensing.omnistrate.cloud"
+
+	defaultTimeAttestationMaxAge = 24 * time.Hour
+	maxTimestampClockSkew        = 5 * time.Minute
 )
@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/pkg/errors"
+)
+
+// EnforcementMode controls what happens when license validation fails.
+type EnforcementMode string
+
+const (
+	// EnforcementModeEnforce fails validation when the license is invalid. It is the default.
+	EnforcementModeEnforce EnforcementMode = "enforce"
+	// EnforcementModeWarn logs failures of the license claims, such as an expired license, and reports them as
+	// warnings instead of errors.
+	EnforcementModeWarn EnforcementMode = "warn"
+	// EnforcementModeAudit logs the outcome of every validation, and only fails when the license cannot be
+	// trusted.
+	EnforcementModeAudit EnforcementMode = "audit"
+)
+
+func (m EnforcementMode) IsValid() bool {
+	switch m {
+	case EnforcementModeEnforce, EnforcementModeWarn, EnforcementModeAudit:
+		return true
+	default:
+		return false
+	}
+}
+
+// Reason is a stable code describing the outcome of a validation, suitable for metrics and logs.
+type Reason string
+
+const (
+	ReasonValid              Reason = "valid"
+	ReasonUnavailable        Reason = "unavailable"
+	ReasonExpired            Reason = "expired"
+	ReasonNotYetValid        Reason = "not_yet_valid"
+	ReasonMismatch           Reason = "mismatch"
+	ReasonInvalidSignature   Reason = "invalid_signature"
+	ReasonInvalidCertificate Reason = "invalid_certificate"
+	ReasonClockRollback      Reason = "clock_rollback"
+	ReasonUntrustedTime      Reason = "untrusted_time"
+	ReasonInvalidTimestamp   Reason = "invalid_timestamp"
+	ReasonInvalid            Reason = "invalid"
+)
+
+// IsRelaxable reports whether warn and audit modes may accept a license failing for the reason. Only failures
+// of the license claims are relaxed: a license that is unavailable or whose signature, signing certificate or
+// timestamp does not verify is rejected in every mode.
+func (r Reason) IsRelaxable() bool {
+	switch r {
+	case ReasonExpired, ReasonNotYetValid, ReasonMismatch, ReasonClockRollback:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReasonFor returns the reason code for a validation error.
+func ReasonFor(err error) Reason {
+	switch {
+	case err == nil:
+		return ReasonValid
+	case errors.Is(err, ErrLicenseExpired):
+		return ReasonExpired
+	case errors.Is(err, ErrLicenseNotYetValid):
+		return ReasonNotYetValid
+	case errors.Is(err, ErrLicenseMismatch), errors.Is(err, ErrInstanceIDConflict):
+		return ReasonMismatch
+	case errors.Is(err, ErrInvalidSignature):
+		return ReasonInvalidSignature
+	case errors.Is(err, ErrInvalidCertificate):
+		return ReasonInvalidCertificate
+	case errors.Is(err, ErrClockRollback), errors.Is(err, ErrHighWaterMarkInvalid):
+		return ReasonClockRollback
+	case errors.Is(err, ErrInvalidTimeAttestation), errors.Is(err, ErrTimeAttestationStale):
+		return ReasonUntrustedTime
+	case errors.Is(err, ErrInvalidTimestamp), errors.Is(err, ErrTimestampRequired):
+		return ReasonInvalidTimestamp
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return ReasonUnavailable
+	default:
+		return ReasonInvalid
+	}
+}
+
+// validationReason is ReasonFor, reporting licenses that could not be read or decoded as unavailable.
+func validationReason(envelope *common.LicenseEnvelope, err error) Reason {
+	reason := ReasonFor(err)
+	if reason == ReasonInvalid && envelope == nil {
+		return ReasonUnavailable
+	}
+	return reason
+}
+
+// ValidationResult is the outcome of a validation and the enforcement mode applied to it.
+type ValidationResult struct {
+	Mode   EnforcementMode
+	Reason Reason
+	// Err is the validation failure, reported whatever the mode.
+	Err error
+	// Warning is set when the validation failed but the mode did not enforce the failure, which is only the case
+	// for failures of the license claims.
+	Warning bool
+	// Envelope is the license that was validated. It is nil when the license could not be read or decoded, and
+	// its claims are only trustworthy when Verified is set.
+	Envelope *common.LicenseEnvelope
+	// Verified reports whether the signature of Envelope was verified against a trusted signing certificate,
+	// even when the license is not valid for other reasons such as being expired.
+	Verified bool
+	// CertificateExpiresAt is the expiration of the signing certificate, or zero when it could not be read.
+	CertificateExpiresAt time.Time
+	// InstanceID is the instance ID the license was matched against and where it came from.
+	InstanceID DiscoveredInstanceID
+}
+
+func (r *ValidationResult) IsValid() bool {
+	return r != nil && r.Err == nil
+}
+
+// Error returns the failure to report to the caller, which is set unless the mode relaxes it.
+func (r *ValidationResult) Error() error {
+	if r.Mode == EnforcementModeEnforce || !r.Reason.IsRelaxable() {
+		return r.Err
+	}
+	return nil
+}
+
+// enforcementMode returns the mode set in code, then in the environment when allowed. Unknown modes enforce
+// the license.
+func (options ValidationOptions) enforcementMode(config *ValidatorConfig) EnforcementMode {
+	mode := options.EnforcementMode
+	if mode == "" && options.EnforcementModeFromEnv {
+		mode = config.EnforcementMode
+	}
+	if mode == "" {
+		return EnforcementModeEnforce
+	}
+	if !mode.IsValid() {
+		options.logger().Warn("unknown license enforcement mode, enforcing the license", slog.String("mode", string(mode)))
+		return EnforcementModeEnforce
+	}
+	return mode
+}
+
+func (options ValidationOptions) logger() *slog.Logger {
+	if options.Logger != nil {
+		return options.Logger
+	}
+	return slog.Default()
+}
+
+// newValidationResult applies the enforcement mode to a validation outcome and logs it. Only the license
+// identifier and the failure are logged, never the license contents.
+func newValidationResult(options ValidationOptions, mode EnforcementMode, envelope *common.LicenseEnvelope, err error) *ValidationResult {
+	result := &ValidationResult{
+		Mode:     mode,
+		Reason:   validationReason(envelope, err),
+		Err:      err,
+		Envelope: envelope,
+	}
+
+	attrs := []any{
+		slog.String("mode", string(mode)),
+		slog.String("reason", string(result.Reason)),
+	}
+	if envelope.IsValid() {
+		attrs = append(attrs, slog.String("license_id", envelope.License.ID))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	switch mode {
+	case EnforcementModeWarn:
+		if err != nil && result.Reason.IsRelaxable() {
+			result.Warning = true
+			options.logger().Warn("license validation failed, not enforced", attrs...)
+		}
+	case EnforcementModeAudit:
+		options.logger().Info("license validation audited", attrs...)
+	}
+	return result
+}
@@ -0,0 +1,177 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLicenseWithResult_EnforcementModes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(time.Hour))
+	require.NoError(t, err)
+
+	newOptions := func(mode EnforcementMode, logs *bytes.Buffer) ValidationOptions {
+		return ValidationOptions{
+			SkipCertificateValidation: true,
+			LicenseSource:             NewBytesSource([]byte(envelope.String())),
+			CertSource:                NewBytesSource(certPEM),
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+			CurrentTime:               now.Add(2 * time.Hour),
+			EnforcementMode:           mode,
+			Logger:                    slog.New(slog.NewJSONHandler(logs, nil)),
+		}
+	}
+
+	var logs bytes.Buffer
+	result := ValidateLicenseWithResult(newOptions(EnforcementModeEnforce, &logs))
+	assert.Equal(t, EnforcementModeEnforce, result.Mode)
+	assert.Equal(t, ReasonExpired, result.Reason)
+	assert.False(t, result.Warning)
+	require.ErrorIs(t, result.Error(), ErrLicenseExpired)
+	require.ErrorIs(t, ValidateLicenseWithOptions(newOptions(EnforcementModeEnforce, &logs)), ErrLicenseExpired)
+	assert.Empty(t, logs.String())
+
+	result = ValidateLicenseWithResult(newOptions(EnforcementModeWarn, &logs))
+	assert.Equal(t, EnforcementModeWarn, result.Mode)
+	assert.Equal(t, ReasonExpired, result.Reason)
+	assert.True(t, result.Warning)
+	assert.False(t, result.IsValid())
+	require.ErrorIs(t, result.Err, ErrLicenseExpired)
+	require.NoError(t, result.Error())
+	assert.Contains(t, logs.String(), `"level":"WARN"`)
+	assert.Contains(t, logs.String(), `"reason":"expired"`)
+	assert.Contains(t, logs.String(), envelope.License.ID)
+	assert.NotContains(t, logs.String(), base64.StdEncoding.EncodeToString(envelope.Signature))
+	assert.NotContains(t, logs.String(), "product a")
+
+	logs.Reset()
+	result = ValidateLicenseWithResult(newOptions(EnforcementModeAudit, &logs))
+	assert.Equal(t, EnforcementModeAudit, result.Mode)
+	assert.False(t, result.Warning)
+	require.NoError(t, result.Error())
+	assert.Contains(t, logs.String(), `"level":"INFO"`)
+	assert.Contains(t, logs.String(), `"mode":"audit"`)
+
+	// Audit also records successful validations
+	logs.Reset()
+	options := newOptions(EnforcementModeAudit, &logs)
+	options.CurrentTime = now
+	result = ValidateLicenseWithResult(options)
+	assert.True(t, result.IsValid())
+	assert.Equal(t, ReasonValid, result.Reason)
+	assert.Contains(t, logs.String(), `"reason":"valid"`)
+
+	// Unknown modes enforce the license
+	logs.Reset()
+	result = ValidateLicenseWithResult(newOptions("lenient", &logs))
+	assert.Equal(t, EnforcementModeEnforce, result.Mode)
+	require.ErrorIs(t, result.Error(), ErrLicenseExpired)
+	assert.Contains(t, logs.String(), "unknown license enforcement mode")
+}
+
+func TestValidateLicenseWithResult_EnforcementModesUntrusted(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(time.Hour))
+	require.NoError(t, err)
+	valid := envelope.String()
+	envelope.License.Features = []string{"sso"}
+	tampered := envelope.String()
+
+	newOptions := func(mode EnforcementMode, license string) ValidationOptions {
+		return ValidationOptions{
+			SkipCertificateValidation: true,
+			LicenseSource:             NewBytesSource([]byte(license)),
+			CertSource:                NewBytesSource(certPEM),
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+			CurrentTime:               now,
+			EnforcementMode:           mode,
+			Logger:                    slog.New(slog.DiscardHandler),
+		}
+	}
+
+	// Licenses that cannot be trusted are rejected whatever the mode
+	for _, mode := range []EnforcementMode{EnforcementModeWarn, EnforcementModeAudit} {
+		result := ValidateLicenseWithResult(newOptions(mode, tampered))
+		assert.Equal(t, ReasonInvalidSignature, result.Reason)
+		assert.False(t, result.Warning)
+		require.ErrorIs(t, result.Error(), ErrInvalidSignature)
+		require.ErrorIs(t, ValidateLicenseWithOptions(newOptions(mode, tampered)), ErrInvalidSignature)
+
+		// The test certificate is not issued for the licensing domain
+		options := newOptions(mode, valid)
+		options.SkipCertificateValidation = false
+		result = ValidateLicenseWithResult(options)
+		assert.Equal(t, ReasonInvalidCertificate, result.Reason)
+		assert.False(t, result.Warning)
+		require.Error(t, result.Error())
+	}
+}
+
+func TestValidateLicenseWithResult_EnforcementModeFromEnv(t *testing.T) {
+	t.Setenv(licenseEnforcementModeEnv, string(EnforcementModeWarn))
+
+	options := ValidationOptions{
+		LicenseSource: NewBytesSource([]byte("not a license")),
+		CertSource:    NewBytesSource(certPEM),
+		Logger:        slog.New(slog.DiscardHandler),
+	}
+
+	// The environment is ignored unless allowed
+	result := ValidateLicenseWithResult(options)
+	assert.Equal(t, EnforcementModeEnforce, result.Mode)
+	require.Error(t, ValidateLicenseWithOptions(options))
+
+	options.EnforcementModeFromEnv = true
+	result = ValidateLicenseWithResult(options)
+	assert.Equal(t, EnforcementModeWarn, result.Mode)
+	assert.Equal(t, ReasonUnavailable, result.Reason)
+	// A license that cannot be read is not relaxed
+	assert.False(t, result.Warning)
+	require.Error(t, ValidateLicenseWithOptions(options))
+
+	// The mode set in code takes precedence over the environment
+	options.EnforcementMode = EnforcementModeEnforce
+	result = ValidateLicenseWithResult(options)
+	assert.Equal(t, EnforcementModeEnforce, result.Mode)
+	require.Error(t, result.Error())
+}
+
+func TestReasonFor(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	validator, err := NewValidatorFromBytes(certPEM)
+	require.NoError(t, err)
+
+	assert.Equal(t, ReasonValid, ReasonFor(validator.ValidateLicense(envelope, "orgId", "SKU", "instance-1", time.Now())))
+	assert.Equal(t, ReasonMismatch, ReasonFor(validator.ValidateLicense(envelope, "otherOrgId", "SKU", "instance-1", time.Now())))
+	assert.Equal(t, ReasonExpired, ReasonFor(validator.ValidateLicense(envelope, "orgId", "SKU", "instance-1", time.Now().Add(2*time.Hour))))
+	assert.Equal(t, ReasonNotYetValid, ReasonFor(ErrLicenseNotYetValid))
+	assert.Equal(t, ReasonInvalidCertificate, ReasonFor(validator.ValidateCertificate("licensing.omnistrate.cloud", time.Now())))
+
+	envelope.License.Description = "tampered"
+	assert.Equal(t, ReasonInvalidSignature, ReasonFor(validator.ValidateLicense(envelope, "orgId", "SKU", "instance-1", time.Now())))
+}
@@ -0,0 +1,28 @@
+package validator
+
+import "github.com/pkg/errors"
+
+var (
+	ErrLicenseExpired     = errors.New("license is expired")
+	ErrLicenseNotYetValid = errors.New("license is not yet valid")
+	ErrLicenseMismatch    = errors.New("license is invalid")
+	ErrInvalidSignature   = errors.New("failed to verify signature")
+	ErrInvalidCertificate = errors.New("signing certificate is invalid")
+	// ErrClockRollback is returned when the system clock is behind the last trusted time seen.
+	ErrClockRollback = errors.New("system clock was rolled back")
+	// ErrHighWaterMarkInvalid is returned when the persisted high-water mark is corrupt, was tampered with or is
+	// missing once it was written.
+	ErrHighWaterMarkInvalid = errors.New("high-water mark is invalid")
+	// ErrInvalidTimeAttestation is returned when a time attestation is malformed or not signed by the license issuer.
+	ErrInvalidTimeAttestation = errors.New("time attestation is invalid")
+	// ErrTimeAttestationStale is returned when a time attestation is older than the freshness window.
+	ErrTimeAttestationStale = errors.New("time attestation is stale")
+	// ErrInvalidTimestamp is returned when the timestamp token of a license does not verify.
+	ErrInvalidTimestamp = errors.New("license timestamp is invalid")
+	// ErrTimestampRequired is returned when a timestamp token is required but the license has none.
+	ErrTimestampRequired = errors.New("license timestamp is required")
+	// ErrInstanceIDConflict is returned when instance ID sources discover different instance IDs.
+	ErrInstanceIDConflict = errors.New("instance id sources disagree")
+	// ErrHostFingerprintMismatch is returned when a node-locked license does not match the current host.
+	ErrHostFingerprintMismatch = errors.New("host fingerprint does not match")
+)
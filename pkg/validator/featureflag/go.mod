@@ -0,0 +1,17 @@
+module github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator/featureflag
+
+go 1.25.3
+
+require (
+	github.com/omnistrate-oss/omnistrate-licensing-sdk-go v0.1.0
+	github.com/open-feature/go-sdk v1.18.0
+	github.com/stretchr/testify v1.12.1
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	go.uber.org/mock v0.6.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/text v0.40.0 // indirect
+)
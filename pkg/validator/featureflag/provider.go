@@ -0,0 +1,304 @@
+// Package featureflag resolves OpenFeature flags from the entitlements of the validated license.
+package featureflag
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+const (
+	ProviderName = "omnistrate-license"
+
+	// EntitlementsFlag is an object flag resolving to the features and limits of the license.
+	EntitlementsFlag = "license.entitlements"
+	// PlanFlag is a string flag resolving to the product plan unique ID of the license.
+	PlanFlag = "license.plan"
+	// OrganizationFlag is a string flag resolving to the organization ID of the license.
+	OrganizationFlag = "license.organization"
+
+	// FlagMetadataLicenseState and FlagMetadataLicenseReason are the flag metadata keys reporting the license status.
+	FlagMetadataLicenseState  = "licenseState"
+	FlagMetadataLicenseReason = "licenseReason"
+
+	VariantEnabled  = "enabled"
+	VariantDisabled = "disabled"
+)
+
+type Option func(*Provider)
+
+// WithEventBuffer sets how many configuration change events are buffered before older changes are dropped.
+// Defaults to 16.
+func WithEventBuffer(size int) Option {
+	return func(p *Provider) {
+		p.bufferSize = size
+	}
+}
+
+// Provider is an OpenFeature provider backed by the license status reported by a provider, usually a Watcher.
+//
+// Boolean flags resolve to whether the license includes the feature, integer and float flags to the license
+// limits, and string flags to the license metadata. Entitlements the license does not grant resolve to the
+// default value with the DISABLED reason. Flags resolve to the default value with the ERROR reason while the
+// license is expired, its signature is not verified, or it is not validated yet. Other invalid licenses only
+// resolve flags when the watcher runs in warn or audit mode.
+type Provider struct {
+	openfeature.NoopStateHandler
+
+	provider   validator.StatusProvider
+	bufferSize int
+	events     chan openfeature.Event
+
+	mu   sync.Mutex
+	last *validator.LicenseStatus
+}
+
+func NewProvider(provider validator.StatusProvider, opts ...Option) *Provider {
+	p := &Provider{
+		provider:   provider,
+		bufferSize: 16,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.events = make(chan openfeature.Event, p.bufferSize)
+	p.last = provider.Status()
+	return p
+}
+
+func (p *Provider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: ProviderName}
+}
+
+func (p *Provider) Hooks() []openfeature.Hook {
+	return nil
+}
+
+// EventChannel emits a PROVIDER_CONFIGURATION_CHANGED event whenever Observe sees a different license or
+// license state.
+func (p *Provider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+// Observe records a license status, and notifies OpenFeature clients when the license or its state changed.
+// Set it as the watcher's OnCheck callback so flags follow license file updates.
+func (p *Provider) Observe(status *validator.LicenseStatus) {
+	p.mu.Lock()
+	previous := p.last
+	p.last = status
+	p.mu.Unlock()
+
+	changes := changedFlags(previous, status)
+	if len(changes) == 0 && stateOf(previous) == stateOf(status) {
+		return
+	}
+
+	event := openfeature.Event{
+		ProviderName: ProviderName,
+		EventType:    openfeature.ProviderConfigChange,
+		ProviderEventDetails: openfeature.ProviderEventDetails{
+			Message:     "license changed",
+			FlagChanges: changes,
+			EventMetadata: map[string]any{
+				FlagMetadataLicenseState:  string(stateOf(status)),
+				FlagMetadataLicenseReason: string(reasonOf(status)),
+			},
+		},
+	}
+	select {
+	case p.events <- event:
+	default:
+		// Evaluations always read the latest status, so a dropped event only delays cache invalidation
+	}
+}
+
+func (p *Provider) BooleanEvaluation(_ context.Context, flag string, defaultValue bool, _ openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	license, detail := p.resolve()
+	if license == nil {
+		return openfeature.BoolResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	if !license.HasFeature(flag) {
+		detail.Reason = openfeature.DisabledReason
+		detail.Variant = VariantDisabled
+		return openfeature.BoolResolutionDetail{Value: false, ProviderResolutionDetail: detail}
+	}
+	detail.Variant = VariantEnabled
+	return openfeature.BoolResolutionDetail{Value: true, ProviderResolutionDetail: detail}
+}
+
+func (p *Provider) StringEvaluation(_ context.Context, flag string, defaultValue string, _ openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	license, detail := p.resolve()
+	if license == nil {
+		return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+
+	var value string
+	var ok bool
+	switch flag {
+	case PlanFlag:
+		value, ok = license.ProductPlanUniqueID, true
+	case OrganizationFlag:
+		value, ok = license.OrganizationID, true
+	default:
+		value, ok = license.Metadata[flag]
+	}
+	if !ok {
+		detail.Reason = openfeature.DisabledReason
+		return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	return openfeature.StringResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+func (p *Provider) IntEvaluation(_ context.Context, flag string, defaultValue int64, _ openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	license, detail := p.resolve()
+	if license == nil {
+		return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	limit, ok := license.GetLimit(flag)
+	if !ok {
+		detail.Reason = openfeature.DisabledReason
+		return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	return openfeature.IntResolutionDetail{Value: limit, ProviderResolutionDetail: detail}
+}
+
+func (p *Provider) FloatEvaluation(_ context.Context, flag string, defaultValue float64, _ openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	license, detail := p.resolve()
+	if license == nil {
+		return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	limit, ok := license.GetLimit(flag)
+	if !ok {
+		detail.Reason = openfeature.DisabledReason
+		return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	return openfeature.FloatResolutionDetail{Value: float64(limit), ProviderResolutionDetail: detail}
+}
+
+// ObjectEvaluation resolves EntitlementsFlag to a map with the "features" and "limits" of the license. Other
+// flags resolve to true for features and to the limit for limits.
+func (p *Provider) ObjectEvaluation(_ context.Context, flag string, defaultValue any, _ openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	license, detail := p.resolve()
+	if license == nil {
+		return openfeature.InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+
+	if flag == EntitlementsFlag {
+		features := make([]any, 0, len(license.Features))
+		for _, feature := range license.Features {
+			features = append(features, feature)
+		}
+		limits := make(map[string]any, len(license.Limits))
+		for name, limit := range license.Limits {
+			limits[name] = limit
+		}
+		return openfeature.InterfaceResolutionDetail{
+			Value:                    map[string]any{"features": features, "limits": limits},
+			ProviderResolutionDetail: detail,
+		}
+	}
+	if limit, ok := license.GetLimit(flag); ok {
+		return openfeature.InterfaceResolutionDetail{Value: limit, ProviderResolutionDetail: detail}
+	}
+	if license.HasFeature(flag) {
+		detail.Variant = VariantEnabled
+		return openfeature.InterfaceResolutionDetail{Value: true, ProviderResolutionDetail: detail}
+	}
+	detail.Reason = openfeature.DisabledReason
+	return openfeature.InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+}
+
+// resolve returns the license flags resolve from, or nil and an ERROR resolution when flags must fall back to
+// their default value.
+func (p *Provider) resolve() (*common.License, openfeature.ProviderResolutionDetail) {
+	status := p.provider.Status()
+	detail := openfeature.ProviderResolutionDetail{
+		Reason: openfeature.StaticReason,
+		FlagMetadata: openfeature.FlagMetadata{
+			FlagMetadataLicenseState:  string(stateOf(status)),
+			FlagMetadataLicenseReason: string(reasonOf(status)),
+		},
+	}
+
+	license := status.License()
+	switch {
+	case stateOf(status) == validator.LicenseStateUnknown:
+		detail.Reason = openfeature.ErrorReason
+		detail.ResolutionError = openfeature.NewProviderNotReadyResolutionError("license has not been validated yet")
+		return nil, detail
+	case license == nil || status.State == validator.LicenseStateExpired || (!status.IsValid() && status.IsEnforced()):
+		// Entitlements are never read from an unverified license, whatever the enforcement mode
+		// The validation error is not exposed to flag evaluations
+		detail.Reason = openfeature.ErrorReason
+		detail.ResolutionError = openfeature.NewGeneralResolutionError("license is not valid: " + string(reasonOf(status)))
+		return nil, detail
+	}
+	return license, detail
+}
+
+func stateOf(status *validator.LicenseStatus) validator.LicenseState {
+	if status == nil || status.State == "" {
+		return validator.LicenseStateUnknown
+	}
+	return status.State
+}
+
+func reasonOf(status *validator.LicenseStatus) validator.Reason {
+	if status == nil || status.Reason == "" {
+		return validator.ReasonUnavailable
+	}
+	return status.Reason
+}
+
+// changedFlags returns the features, limits and metadata keys that differ between the licenses of two statuses.
+func changedFlags(previous, current *validator.LicenseStatus) []string {
+	before, after := previous.License(), current.License()
+	if before == nil {
+		before = &common.License{}
+	}
+	if after == nil {
+		after = &common.License{}
+	}
+
+	changes := map[string]struct{}{}
+	for _, feature := range slices.Concat(before.Features, after.Features) {
+		if before.HasFeature(feature) != after.HasFeature(feature) {
+			changes[feature] = struct{}{}
+		}
+	}
+	for name := range maps.Keys(before.Limits) {
+		if limit, ok := after.Limits[name]; !ok || limit != before.Limits[name] {
+			changes[name] = struct{}{}
+		}
+	}
+	for name := range maps.Keys(after.Limits) {
+		if _, ok := before.Limits[name]; !ok {
+			changes[name] = struct{}{}
+		}
+	}
+	if len(changes) > 0 {
+		changes[EntitlementsFlag] = struct{}{}
+	}
+	for key := range maps.Keys(before.Metadata) {
+		if value, ok := after.Metadata[key]; !ok || value != before.Metadata[key] {
+			changes[key] = struct{}{}
+		}
+	}
+	for key := range maps.Keys(after.Metadata) {
+		if _, ok := before.Metadata[key]; !ok {
+			changes[key] = struct{}{}
+		}
+	}
+	if before.ProductPlanUniqueID != after.ProductPlanUniqueID {
+		changes[PlanFlag] = struct{}{}
+	}
+	if before.OrganizationID != after.OrganizationID {
+		changes[OrganizationFlag] = struct{}{}
+	}
+	return slices.Sorted(maps.Keys(changes))
+}
@@ -0,0 +1,226 @@
+package featureflag
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/internal/testcert"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/isolated"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var keyPEM, certPEM = testcert.New()
+
+func generateLicense(t *testing.T, expirationTime time.Time, opts ...generator.LicenseOption) *common.LicenseEnvelope {
+	t.Helper()
+
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+	envelope, err := manager.GenerateLicenseFromRequest(generator.NewLicenseRequest("orgId", "SKU", expirationTime, opts...))
+	require.NoError(t, err)
+	return envelope
+}
+
+func newTestClient(t *testing.T, provider *Provider) *openfeature.Client {
+	t.Helper()
+
+	api := isolated.NewAPI()
+	t.Cleanup(func() { _ = api.Shutdown(context.Background()) })
+	require.NoError(t, api.SetProviderAndWait(context.Background(), provider))
+	return api.NewClient()
+}
+
+func receiveChange(t *testing.T, changes <-chan openfeature.EventDetails) openfeature.EventDetails {
+	t.Helper()
+
+	select {
+	case details := <-changes:
+		return details
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for a configuration change")
+	}
+	return openfeature.EventDetails{}
+}
+
+func TestProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	envelope := generateLicense(t, time.Now().Add(time.Hour),
+		generator.WithFeatures("sso"),
+		generator.WithLimit("seats", 25),
+		generator.WithMetadata("tier", "gold"))
+	status := &validator.LicenseStatus{State: validator.LicenseStateValid, Reason: validator.ReasonValid, Envelope: envelope, Verified: true}
+	client := newTestClient(t, NewProvider(validator.StatusProviderFunc(func() *validator.LicenseStatus { return status })))
+	evalCtx := openfeature.EvaluationContext{}
+
+	sso, err := client.BooleanValueDetails(ctx, "sso", false, evalCtx)
+	require.NoError(t, err)
+	assert.True(t, sso.Value)
+	assert.Equal(t, openfeature.StaticReason, sso.Reason)
+	assert.Equal(t, VariantEnabled, sso.Variant)
+	assert.Equal(t, "valid", sso.FlagMetadata[FlagMetadataLicenseState])
+
+	audit, err := client.BooleanValueDetails(ctx, "audit-log", true, evalCtx)
+	require.NoError(t, err)
+	assert.False(t, audit.Value)
+	assert.Equal(t, openfeature.DisabledReason, audit.Reason)
+
+	seats, err := client.IntValueDetails(ctx, "seats", 1, evalCtx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), seats.Value)
+	assert.InDelta(t, 25.0, client.Float(ctx, "seats", 1, evalCtx), 0)
+	projects, err := client.IntValueDetails(ctx, "projects", 3, evalCtx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), projects.Value)
+	assert.Equal(t, openfeature.DisabledReason, projects.Reason)
+
+	assert.Equal(t, "gold", client.String(ctx, "tier", "", evalCtx))
+	assert.Equal(t, "SKU", client.String(ctx, PlanFlag, "", evalCtx))
+	assert.Equal(t, "orgId", client.String(ctx, OrganizationFlag, "", evalCtx))
+
+	entitlements, err := client.ObjectValueDetails(ctx, EntitlementsFlag, nil, evalCtx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"features": []any{"sso"},
+		"limits":   map[string]any{"seats": int64(25)},
+	}, entitlements.Value)
+
+	// An expired license resolves every flag to its default value
+	status = &validator.LicenseStatus{State: validator.LicenseStateExpired, Reason: validator.ReasonExpired, Envelope: envelope, Verified: true}
+	sso, err = client.BooleanValueDetails(ctx, "sso", false, evalCtx)
+	require.Error(t, err)
+	assert.False(t, sso.Value)
+	assert.Equal(t, openfeature.ErrorReason, sso.Reason)
+	assert.Equal(t, openfeature.GeneralCode, sso.ErrorCode)
+	assert.Equal(t, "expired", sso.FlagMetadata[FlagMetadataLicenseReason])
+	assert.Equal(t, int64(1), client.Int(ctx, "seats", 1, evalCtx))
+
+	// Even when the license is not enforced
+	status.Mode = validator.EnforcementModeWarn
+	sso, err = client.BooleanValueDetails(ctx, "sso", false, evalCtx)
+	require.Error(t, err)
+	assert.Equal(t, openfeature.ErrorReason, sso.Reason)
+
+	// Warn mode resolves flags from other invalid licenses
+	status = &validator.LicenseStatus{State: validator.LicenseStateInvalid, Reason: validator.ReasonMismatch, Mode: validator.EnforcementModeWarn, Envelope: envelope, Verified: true}
+	assert.True(t, client.Boolean(ctx, "sso", false, evalCtx))
+
+	// But never from a license whose signature was not verified
+	status = &validator.LicenseStatus{State: validator.LicenseStateInvalid, Reason: validator.ReasonInvalidSignature, Mode: validator.EnforcementModeWarn, Envelope: envelope}
+	sso, err = client.BooleanValueDetails(ctx, "sso", false, evalCtx)
+	require.Error(t, err)
+	assert.False(t, sso.Value)
+	assert.Equal(t, openfeature.ErrorReason, sso.Reason)
+	assert.Equal(t, int64(1), client.Int(ctx, "seats", 1, evalCtx))
+
+	status = &validator.LicenseStatus{State: validator.LicenseStateUnknown}
+	sso, err = client.BooleanValueDetails(ctx, "sso", false, evalCtx)
+	require.Error(t, err)
+	assert.Equal(t, openfeature.ErrorReason, sso.Reason)
+	assert.Equal(t, openfeature.ProviderNotReadyCode, sso.ErrorCode)
+}
+
+func TestProvider_Watcher(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	licensePath := filepath.Join(dir, "license.lic")
+	certPath := filepath.Join(dir, "license.crt")
+	writeFile := func(content string) {
+		require.NoError(t, os.WriteFile(licensePath+".tmp", []byte(content), 0o600))
+		require.NoError(t, os.Rename(licensePath+".tmp", licensePath))
+	}
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0o600))
+	writeFile(generateLicense(t, time.Now().Add(48*time.Hour), generator.WithFeatures("sso")).String())
+
+	var provider *Provider
+	watcher := validator.NewWatcher(validator.WatcherOptions{
+		ValidationOptions: validator.ValidationOptions{
+			SkipCertificateValidation: true,
+			CertPath:                  certPath,
+			LicensePath:               licensePath,
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+		},
+		Interval:     time.Hour,
+		PollInterval: 10 * time.Millisecond,
+		OnCheck:      func(status *validator.LicenseStatus) { provider.Observe(status) },
+	})
+	provider = NewProvider(watcher)
+	client := newTestClient(t, provider)
+
+	changes := make(chan openfeature.EventDetails, 10)
+	callback := func(details openfeature.EventDetails) { changes <- details }
+	client.AddHandler(openfeature.ProviderConfigChange, &callback)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = watcher.Run(ctx) }()
+
+	details := receiveChange(t, changes)
+	assert.Equal(t, []string{EntitlementsFlag, OrganizationFlag, PlanFlag, "sso"}, details.FlagChanges)
+	assert.Equal(t, "valid", details.EventMetadata[FlagMetadataLicenseState])
+	assert.True(t, client.Boolean(ctx, "sso", false, openfeature.EvaluationContext{}))
+
+	// Replacing the license file updates the flags
+	writeFile(generateLicense(t, time.Now().Add(48*time.Hour), generator.WithFeatures("audit-log")).String())
+	details = receiveChange(t, changes)
+	assert.Equal(t, []string{"audit-log", EntitlementsFlag, "sso"}, details.FlagChanges)
+	assert.False(t, client.Boolean(ctx, "sso", false, openfeature.EvaluationContext{}))
+	assert.True(t, client.Boolean(ctx, "audit-log", false, openfeature.EvaluationContext{}))
+
+	// An expired license changes the state of every flag
+	writeFile(generateLicense(t, time.Now().Add(-time.Hour), generator.WithFeatures("audit-log")).String())
+	details = receiveChange(t, changes)
+	assert.Equal(t, "expired", details.EventMetadata[FlagMetadataLicenseState])
+	result, err := client.BooleanValueDetails(ctx, "audit-log", false, openfeature.EvaluationContext{})
+	require.Error(t, err)
+	assert.Equal(t, openfeature.ErrorReason, result.Reason)
+}
+
+func TestProvider_WarnModeInvalidSignature(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	licensePath := filepath.Join(dir, "license.lic")
+	certPath := filepath.Join(dir, "license.crt")
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0o600))
+
+	// A license granting features it was not signed with
+	envelope := generateLicense(t, time.Now().Add(48*time.Hour))
+	envelope.License.Features = []string{"sso"}
+	envelope.License.Limits = map[string]int64{"seats": 1000}
+	require.NoError(t, os.WriteFile(licensePath, []byte(envelope.String()), 0o600))
+
+	watcher := validator.NewWatcher(validator.WatcherOptions{
+		ValidationOptions: validator.ValidationOptions{
+			SkipCertificateValidation: true,
+			CertPath:                  certPath,
+			LicensePath:               licensePath,
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			EnforcementMode:           validator.EnforcementModeWarn,
+		},
+	})
+	watcher.Check()
+	client := newTestClient(t, NewProvider(watcher))
+	ctx := context.Background()
+
+	sso, err := client.BooleanValueDetails(ctx, "sso", false, openfeature.EvaluationContext{})
+	require.Error(t, err)
+	assert.False(t, sso.Value)
+	assert.Equal(t, openfeature.ErrorReason, sso.Reason)
+	assert.Equal(t, string(validator.ReasonInvalidSignature), sso.FlagMetadata[FlagMetadataLicenseReason])
+	assert.Equal(t, int64(5), client.Int(ctx, "seats", 5, openfeature.EvaluationContext{}))
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// projectedVolumeDataDir is the symlink Kubernetes swaps atomically to publish a new version of a
+	// secret, config map or projected volume. Files at the root of the volume are symlinks into it.
+	projectedVolumeDataDir = "..data"
+
+	maxSnapshotAttempts = 5
+)
+
+// licenseFiles is a license and certificate pair read from the same version of their volume.
+type licenseFiles struct {
+	License []byte
+	Cert    []byte
+	Version string
+	// ModTime is the latest modification time of the files, when read from the file system.
+	ModTime time.Time
+}
+
+// readLicenseFiles reads the license and certificate files. When they live in a Kubernetes atomic writer
+// volume, both files are read through the same resolved "..data" directory, and the read is retried if the
+// directory is swapped in the meantime, so the pair always comes from a single update.
+func readLicenseFiles(licensePath, certPath string) (*licenseFiles, error) {
+	var err error
+	for attempt := 0; attempt < maxSnapshotAttempts; attempt++ {
+		var files *licenseFiles
+		var swapped bool
+		files, swapped, err = tryReadLicenseFiles(licensePath, certPath)
+		if !swapped {
+			return files, err
+		}
+	}
+	return nil, errors.Wrap(err, "license files kept changing while being read")
+}
+
+func tryReadLicenseFiles(licensePath, certPath string) (files *licenseFiles, swapped bool, err error) {
+	licenseTarget := projectedVolumeTarget(licensePath)
+	certTarget := projectedVolumeTarget(certPath)
+
+	licenseFile := resolveProjectedVolumePath(licensePath, licenseTarget)
+	certFile := resolveProjectedVolumePath(certPath, certTarget)
+	license, licenseErr := os.ReadFile(licenseFile)
+	cert, certErr := os.ReadFile(certFile)
+
+	// A swap while reading means the files may come from different versions, or from a removed directory
+	if projectedVolumeTarget(licensePath) != licenseTarget || projectedVolumeTarget(certPath) != certTarget {
+		return nil, true, fmt.Errorf("volume updated while reading license files")
+	}
+	if licenseErr != nil {
+		return nil, false, licenseErr
+	}
+	if certErr != nil {
+		return nil, false, certErr
+	}
+
+	return &licenseFiles{
+		License: license,
+		Cert:    cert,
+		Version: licenseFilesVersion(licensePath, certPath),
+		ModTime: latestModTime(licenseFile, certFile),
+	}, false, nil
+}
+
+func latestModTime(paths ...string) time.Time {
+	var latest time.Time
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// licenseFilesVersion identifies the current version of the license and certificate files. It changes whenever
+// the "..data" directory of an atomic writer volume is swapped, or a plain file is modified.
+func licenseFilesVersion(licensePath, certPath string) string {
+	return fileVersion(licensePath) + "|" + fileVersion(certPath)
+}
+
+func fileVersion(path string) string {
+	if target := projectedVolumeTarget(path); target != "" {
+		return target
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "missing"
+	}
+	return fmt.Sprintf("%d-%d", info.Size(), info.ModTime().UnixNano())
+}
+
+// projectedVolumeTarget returns the directory the "..data" symlink next to path points to,
+// or an empty string when path is not part of an atomic writer volume.
+func projectedVolumeTarget(path string) string {
+	target, err := os.Readlink(filepath.Join(filepath.Dir(path), projectedVolumeDataDir))
+	if err != nil {
+		return ""
+	}
+	return target
+}
+
+func resolveProjectedVolumePath(path, target string) string {
+	if target == "" {
+		return path
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	return filepath.Join(target, filepath.Base(path))
+}
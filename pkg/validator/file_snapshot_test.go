@@ -0,0 +1,188 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// atomicWriter mimics the kubelet atomic writer used for secret and projected volumes: every update is written
+// to a new timestamped directory, published by renaming a "..data" symlink and the previous directory is removed.
+type atomicWriter struct {
+	dir      string
+	sequence atomic.Int64
+}
+
+func (w *atomicWriter) write(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+
+	version, err := w.update(files)
+	require.NoError(t, err)
+	return version
+}
+
+func (w *atomicWriter) update(files map[string][]byte) (string, error) {
+	version := fmt.Sprintf("..2025_01_01_00_00_00.%09d", w.sequence.Add(1))
+	if err := os.Mkdir(filepath.Join(w.dir, version), 0o755); err != nil {
+		return "", err
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(w.dir, version, name), data, 0o600); err != nil {
+			return "", err
+		}
+	}
+
+	previous, _ := os.Readlink(filepath.Join(w.dir, projectedVolumeDataDir))
+	tmpLink := filepath.Join(w.dir, "..data_tmp")
+	if err := os.Symlink(version, tmpLink); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpLink, filepath.Join(w.dir, projectedVolumeDataDir)); err != nil {
+		return "", err
+	}
+
+	for name := range files {
+		link := filepath.Join(w.dir, name)
+		if _, err := os.Lstat(link); os.IsNotExist(err) {
+			if err := os.Symlink(filepath.Join(projectedVolumeDataDir, name), link); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if previous != "" {
+		if err := os.RemoveAll(filepath.Join(w.dir, previous)); err != nil {
+			return "", err
+		}
+	}
+	return version, nil
+}
+
+func TestReadLicenseFiles_ProjectedVolume(t *testing.T) {
+	t.Parallel()
+
+	writer := &atomicWriter{dir: t.TempDir()}
+	licensePath := filepath.Join(writer.dir, "license.lic")
+	certPath := filepath.Join(writer.dir, "license.crt")
+
+	version := writer.write(t, map[string][]byte{"license.lic": []byte("license-1"), "license.crt": []byte("cert-1")})
+	files, err := readLicenseFiles(licensePath, certPath)
+	require.NoError(t, err)
+	assert.Equal(t, "license-1", string(files.License))
+	assert.Equal(t, "cert-1", string(files.Cert))
+	assert.Equal(t, version+"|"+version, files.Version)
+
+	version = writer.write(t, map[string][]byte{"license.lic": []byte("license-2"), "license.crt": []byte("cert-2")})
+	files, err = readLicenseFiles(licensePath, certPath)
+	require.NoError(t, err)
+	assert.Equal(t, "license-2", string(files.License))
+	assert.Equal(t, "cert-2", string(files.Cert))
+	assert.Equal(t, version+"|"+version, licenseFilesVersion(licensePath, certPath))
+}
+
+func TestReadLicenseFiles_ConsistentDuringSwaps(t *testing.T) {
+	t.Parallel()
+
+	writer := &atomicWriter{dir: t.TempDir()}
+	licensePath := filepath.Join(writer.dir, "license.lic")
+	certPath := filepath.Join(writer.dir, "license.crt")
+	writer.write(t, map[string][]byte{"license.lic": []byte("license-0"), "license.crt": []byte("cert-0")})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	var writeErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; ctx.Err() == nil && writeErr == nil; i++ {
+			_, writeErr = writer.update(map[string][]byte{"license.lic": fmt.Appendf(nil, "license-%d", i), "license.crt": fmt.Appendf(nil, "cert-%d", i)})
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	for range 500 {
+		files, err := readLicenseFiles(licensePath, certPath)
+		require.NoError(t, err)
+		assert.Equal(t, strings.TrimPrefix(string(files.License), "license-"), strings.TrimPrefix(string(files.Cert), "cert-"))
+	}
+
+	cancel()
+	wg.Wait()
+	require.NoError(t, writeErr)
+}
+
+func TestReadLicenseFiles_PlainFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	licensePath := filepath.Join(dir, "license.lic")
+	certPath := filepath.Join(dir, "license.crt")
+
+	_, err := readLicenseFiles(licensePath, certPath)
+	assert.Error(t, err)
+	assert.Equal(t, "missing|missing", licenseFilesVersion(licensePath, certPath))
+
+	require.NoError(t, os.WriteFile(licensePath, []byte("license"), 0o600))
+	require.NoError(t, os.WriteFile(certPath, []byte("cert"), 0o600))
+	files, err := readLicenseFiles(licensePath, certPath)
+	require.NoError(t, err)
+	assert.Equal(t, "license", string(files.License))
+	assert.Equal(t, "cert", string(files.Cert))
+
+	version := files.Version
+	require.NoError(t, os.WriteFile(licensePath, []byte("license-2"), 0o600))
+	assert.NotEqual(t, version, licenseFilesVersion(licensePath, certPath))
+}
+
+func TestWatcher_ProjectedVolume(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(48*time.Hour))
+	require.NoError(t, err)
+
+	writer := &atomicWriter{dir: t.TempDir()}
+	writer.write(t, map[string][]byte{"license.lic": []byte(envelope.String()), "license.crt": certPEM})
+
+	renewed := make(chan *LicenseStatus, 1)
+	watcher := NewWatcher(WatcherOptions{
+		ValidationOptions: ValidationOptions{
+			SkipCertificateValidation: true,
+			CertPath:                  filepath.Join(writer.dir, "license.crt"),
+			LicensePath:               filepath.Join(writer.dir, "license.lic"),
+			OrganizationID:            "orgId",
+			InstanceID:                "instance-1",
+		},
+		Interval:     time.Hour,
+		PollInterval: 10 * time.Millisecond,
+		OnRenewed:    func(previous, current *LicenseStatus) { renewed <- current },
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.True(t, watcher.Start(ctx).IsValid())
+
+	renewedEnvelope, err := manager.RenewLicense(envelope, now.Add(72*time.Hour))
+	require.NoError(t, err)
+	writer.write(t, map[string][]byte{"license.lic": []byte(renewedEnvelope.String()), "license.crt": certPEM})
+
+	select {
+	case current := <-renewed:
+		assert.Equal(t, renewedEnvelope.License.Version, current.License().Version)
+	case <-time.After(5 * time.Second):
+		t.Fatal("renewed license was not detected")
+	}
+}
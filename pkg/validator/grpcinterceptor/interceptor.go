@@ -0,0 +1,131 @@
+// Package grpcinterceptor enforces the license in gRPC servers, based on the status reported by a validator.Watcher.
+package grpcinterceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to rejections.
+const ErrorDomain = "licensing.omnistrate.com"
+
+type Option func(*interceptor)
+
+// WithInvalidLicenseCode sets the code of errors returned while the license is invalid or expired.
+// Defaults to codes.FailedPrecondition.
+func WithInvalidLicenseCode(code codes.Code) Option {
+	return func(i *interceptor) {
+		i.invalidCode = code
+	}
+}
+
+// WithMethodFeatures requires license features for methods, keyed by full method name such as
+// "/package.Service/Method". Calls to methods that are not listed only require a valid license.
+func WithMethodFeatures(methodFeatures map[string][]string) Option {
+	return func(i *interceptor) {
+		i.methodFeatures = methodFeatures
+	}
+}
+
+type interceptor struct {
+	provider       validator.StatusProvider
+	invalidCode    codes.Code
+	methodFeatures map[string][]string
+}
+
+func newInterceptor(provider validator.StatusProvider, opts []Option) *interceptor {
+	i := &interceptor{
+		provider:    provider,
+		invalidCode: codes.FailedPrecondition,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// UnaryServerInterceptor rejects calls while the license is invalid or lacks the features required by the method,
+// and otherwise adds the license status to the call context.
+func UnaryServerInterceptor(provider validator.StatusProvider, opts ...Option) grpc.UnaryServerInterceptor {
+	i := newInterceptor(provider, opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor(provider validator.StatusProvider, opts ...Option) grpc.StreamServerInterceptor {
+	i := newInterceptor(provider, opts)
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func (i *interceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	licenseStatus := i.provider.Status()
+	if !licenseStatus.IsEnforced() {
+		return validator.ContextWithLicenseStatus(ctx, licenseStatus), nil
+	}
+
+	// The validation error is not exposed to clients
+	license := licenseStatus.License()
+	if !licenseStatus.IsValid() || license == nil {
+		return nil, rejection(i.invalidCode, validator.RejectionCodeLicenseInvalid, "license is not valid", map[string]string{
+			"reason": string(statusReason(licenseStatus)),
+		})
+	}
+
+	for _, feature := range i.methodFeatures[method] {
+		if !license.HasFeature(feature) {
+			return nil, rejection(codes.PermissionDenied, validator.RejectionCodeFeatureRequired, "license does not include feature "+feature, map[string]string{
+				"feature": feature,
+			})
+		}
+	}
+
+	return validator.ContextWithLicenseStatus(ctx, licenseStatus), nil
+}
+
+func rejection(code codes.Code, reason, message string, metadata map[string]string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   strings.ToUpper(reason),
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func statusReason(licenseStatus *validator.LicenseStatus) validator.Reason {
+	if licenseStatus == nil || licenseStatus.Reason == "" {
+		return validator.ReasonUnavailable
+	}
+	return licenseStatus.Reason
+}
+
+// serverStream overrides the context of a stream with one carrying the license status.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
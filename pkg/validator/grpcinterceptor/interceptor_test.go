@@ -0,0 +1,170 @@
+package grpcinterceptor
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// healthServer records the license found in the context of the calls it serves.
+type healthServer struct {
+	*health.Server
+	licenseID atomic.Value
+}
+
+func (s *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.licenseID.Store(validator.LicenseFromContext(ctx).ID)
+	return s.Server.Check(ctx, req)
+}
+
+func (s *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	s.licenseID.Store(validator.LicenseFromContext(stream.Context()).ID)
+	return s.Server.Watch(req, stream)
+}
+
+func newTestClient(t *testing.T, provider validator.StatusProvider, opts ...Option) (healthpb.HealthClient, *healthServer) {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(provider, opts...)),
+		grpc.StreamInterceptor(StreamServerInterceptor(provider, opts...)),
+	)
+	service := &healthServer{Server: health.NewServer()}
+	healthpb.RegisterHealthServer(server, service)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+	})
+	return healthpb.NewHealthClient(conn), service
+}
+
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	require.Fail(t, "missing ErrorInfo detail")
+	return nil
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+
+	licenseStatus := &validator.LicenseStatus{
+		State:  validator.LicenseStateValid,
+		Reason: validator.ReasonValid,
+		Envelope: &common.LicenseEnvelope{
+			License: &common.License{ID: "license-1", Features: []string{"health"}},
+		},
+		Verified: true,
+	}
+	var current atomic.Pointer[validator.LicenseStatus]
+	current.Store(licenseStatus)
+	provider := validator.StatusProviderFunc(current.Load)
+
+	client, service := newTestClient(t, provider, WithMethodFeatures(map[string][]string{
+		healthpb.Health_Watch_FullMethodName: {"health", "health-watch"},
+	}))
+	ctx := context.Background()
+
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "license-1", service.licenseID.Load())
+
+	// The stream requires a feature the license lacks
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	info := errorInfo(t, err)
+	assert.Equal(t, "LICENSE_FEATURE_REQUIRED", info.GetReason())
+	assert.Equal(t, ErrorDomain, info.GetDomain())
+	assert.Equal(t, "health-watch", info.GetMetadata()["feature"])
+
+	current.Store(&validator.LicenseStatus{
+		State:    validator.LicenseStateValid,
+		Envelope: &common.LicenseEnvelope{License: &common.License{ID: "license-2", Features: []string{"health", "health-watch"}}},
+		Verified: true,
+	})
+	stream, err = client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "license-2", service.licenseID.Load())
+
+	// An expired license rejects every call
+	current.Store(&validator.LicenseStatus{
+		State:    validator.LicenseStateExpired,
+		Reason:   validator.ReasonExpired,
+		Envelope: licenseStatus.Envelope,
+		Err:      validator.ErrLicenseExpired,
+		Verified: true,
+	})
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.NotContains(t, err.Error(), validator.ErrLicenseExpired.Error())
+	info = errorInfo(t, err)
+	assert.Equal(t, "LICENSE_INVALID", info.GetReason())
+	assert.Equal(t, string(validator.ReasonExpired), info.GetMetadata()["reason"])
+
+	stream, err = client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Warn mode lets calls through
+	current.Store(&validator.LicenseStatus{
+		State:    validator.LicenseStateExpired,
+		Reason:   validator.ReasonExpired,
+		Mode:     validator.EnforcementModeWarn,
+		Envelope: licenseStatus.Envelope,
+		Verified: true,
+	})
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+}
+
+func TestInterceptors_UnknownStatus(t *testing.T) {
+	t.Parallel()
+
+	// Calls fail closed before the watcher has validated the license
+	client, _ := newTestClient(t, validator.StatusProviderFunc(func() *validator.LicenseStatus {
+		return &validator.LicenseStatus{State: validator.LicenseStateUnknown}
+	}), WithInvalidLicenseCode(codes.PermissionDenied))
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, string(validator.ReasonUnavailable), errorInfo(t, err).GetMetadata()["reason"])
+}
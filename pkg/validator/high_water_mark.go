@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultHighWaterMarkTolerance = 15 * time.Minute
+	minHighWaterMarkKeySize       = 16
+	maxHighWaterMarkSize          = 4096
+
+	// highWaterMarkDomain separates the MAC of the high-water mark from other uses of the instance key.
+	highWaterMarkDomain = "omnistrate-license-high-water-mark:"
+)
+
+type HighWaterMarkOptions struct {
+	// Path of the file the high-water mark is persisted to.
+	Path string
+	// Key authenticates the persisted high-water mark. It must be specific to the instance and at least 16 bytes.
+	Key []byte
+	// Tolerance is how far the clock may be behind the high-water mark before it is reported as rolled back.
+	// Defaults to 15 minutes.
+	Tolerance time.Duration
+	// RequireExisting fails with ErrHighWaterMarkInvalid when the file does not exist, so deleting it does not
+	// reset the mark across restarts. The file must then be initialized once, such as when the instance is
+	// provisioned, by observing the current time with a HighWaterMark that does not require it.
+	RequireExisting bool
+}
+
+// HighWaterMark persists the latest trusted time seen by the validator, so that rolling back the system clock
+// to keep an expired license alive is detected.
+//
+// The file is created by the first Observe. Once a HighWaterMark has read or written the file, a missing file
+// fails with ErrHighWaterMarkInvalid instead of resetting the mark. Set RequireExisting to also fail when the
+// file is missing on startup.
+type HighWaterMark struct {
+	path            string
+	key             []byte
+	tolerance       time.Duration
+	requireExisting bool
+
+	mu sync.Mutex
+	// persisted is set once the mark was read from or written to the file.
+	persisted bool
+}
+
+type highWaterMarkFile struct {
+	Time string `json:"time"`
+	MAC  []byte `json:"mac"`
+}
+
+func NewHighWaterMark(options HighWaterMarkOptions) (*HighWaterMark, error) {
+	if options.Path == "" {
+		return nil, fmt.Errorf("high-water mark path is required")
+	}
+	if len(options.Key) < minHighWaterMarkKeySize {
+		return nil, fmt.Errorf("high-water mark key must be at least %d bytes", minHighWaterMarkKeySize)
+	}
+	if options.Tolerance <= 0 {
+		options.Tolerance = defaultHighWaterMarkTolerance
+	}
+
+	return &HighWaterMark{
+		path:            options.Path,
+		key:             append([]byte{}, options.Key...),
+		tolerance:       options.Tolerance,
+		requireExisting: options.RequireExisting,
+	}, nil
+}
+
+// Observe checks the current time against the high-water mark, ratcheted forward with the given trusted times,
+// such as license issue times and file modification times. It returns ErrClockRollback when the current time
+// is behind the mark by more than the tolerance, and otherwise persists the new mark.
+func (h *HighWaterMark) Observe(currentTime time.Time, trustedTimes ...time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	mark, err := h.load()
+	if err != nil {
+		return err
+	}
+	for _, trustedTime := range trustedTimes {
+		if trustedTime.After(mark) {
+			mark = trustedTime
+		}
+	}
+
+	if currentTime.Add(h.tolerance).Before(mark) {
+		return errors.Wrapf(ErrClockRollback, "current time %s is behind the last trusted time %s",
+			currentTime.UTC().Format(time.RFC3339), mark.UTC().Format(time.RFC3339))
+	}
+
+	if currentTime.After(mark) {
+		mark = currentTime
+	}
+	if err = h.store(mark); err != nil {
+		return err
+	}
+	h.persisted = true
+	return nil
+}
+
+// Time returns the persisted high-water mark, or the zero time when none was persisted yet and the file is not
+// required.
+func (h *HighWaterMark) Time() (time.Time, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.load()
+}
+
+func (h *HighWaterMark) load() (time.Time, error) {
+	file, err := os.Open(h.path)
+	if os.IsNotExist(err) {
+		if h.persisted || h.requireExisting {
+			return time.Time{}, errors.Wrap(ErrHighWaterMarkInvalid, "high-water mark file is missing")
+		}
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to read high-water mark")
+	}
+	defer file.Close()
+
+	data, err := readLimited(file)
+	if err != nil || len(data) > maxHighWaterMarkSize {
+		return time.Time{}, ErrHighWaterMarkInvalid
+	}
+
+	var content highWaterMarkFile
+	if err = json.Unmarshal(data, &content); err != nil {
+		return time.Time{}, ErrHighWaterMarkInvalid
+	}
+	if !hmac.Equal(content.MAC, h.mac(content.Time)) {
+		return time.Time{}, ErrHighWaterMarkInvalid
+	}
+
+	mark, err := time.Parse(time.RFC3339Nano, content.Time)
+	if err != nil {
+		return time.Time{}, ErrHighWaterMarkInvalid
+	}
+	h.persisted = true
+	return mark, nil
+}
+
+func (h *HighWaterMark) store(mark time.Time) error {
+	value := mark.UTC().Format(time.RFC3339Nano)
+	data, err := json.Marshal(highWaterMarkFile{
+		Time: value,
+		MAC:  h.mac(value),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Replace the file atomically so a crash never leaves a truncated mark behind
+	tmp, err := os.CreateTemp(filepath.Dir(h.path), filepath.Base(h.path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to write high-water mark")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "failed to write high-water mark")
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "failed to write high-water mark")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to write high-water mark")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), h.path), "failed to write high-water mark")
+}
+
+func (h *HighWaterMark) mac(value string) []byte {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(highWaterMarkDomain + value))
+	return mac.Sum(nil)
+}
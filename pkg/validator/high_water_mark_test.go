@@ -0,0 +1,160 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testHighWaterMarkKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNewHighWaterMark(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewHighWaterMark(HighWaterMarkOptions{Key: testHighWaterMarkKey})
+	require.Error(t, err)
+
+	_, err = NewHighWaterMark(HighWaterMarkOptions{Path: filepath.Join(t.TempDir(), "mark"), Key: []byte("short")})
+	require.Error(t, err)
+
+	mark, err := NewHighWaterMark(HighWaterMarkOptions{Path: filepath.Join(t.TempDir(), "mark"), Key: testHighWaterMarkKey})
+	require.NoError(t, err)
+	assert.Equal(t, defaultHighWaterMarkTolerance, mark.tolerance)
+
+	markTime, err := mark.Time()
+	require.NoError(t, err)
+	assert.True(t, markTime.IsZero())
+}
+
+func TestHighWaterMark_Observe(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "mark")
+	mark, err := NewHighWaterMark(HighWaterMarkOptions{Path: path, Key: testHighWaterMarkKey, Tolerance: time.Minute})
+	require.NoError(t, err)
+
+	require.NoError(t, mark.Observe(now))
+	markTime, err := mark.Time()
+	require.NoError(t, err)
+	assert.Equal(t, now, markTime)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// Small drifts within the tolerance are accepted and do not move the mark back
+	require.NoError(t, mark.Observe(now.Add(-30*time.Second)))
+	markTime, err = mark.Time()
+	require.NoError(t, err)
+	assert.Equal(t, now, markTime)
+
+	require.ErrorIs(t, mark.Observe(now.Add(-time.Hour)), ErrClockRollback)
+
+	// Trusted times ratchet the mark forward
+	require.ErrorIs(t, mark.Observe(now.Add(time.Hour), now.Add(2*time.Hour)), ErrClockRollback)
+	require.NoError(t, mark.Observe(now.Add(2*time.Hour), now.Add(90*time.Minute)))
+	markTime, err = mark.Time()
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Hour), markTime)
+
+	// The mark survives restarts
+	reopened, err := NewHighWaterMark(HighWaterMarkOptions{Path: path, Key: testHighWaterMarkKey, Tolerance: time.Minute})
+	require.NoError(t, err)
+	require.ErrorIs(t, reopened.Observe(now), ErrClockRollback)
+}
+
+func TestHighWaterMark_Tampered(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "mark")
+	mark, err := NewHighWaterMark(HighWaterMarkOptions{Path: path, Key: testHighWaterMarkKey})
+	require.NoError(t, err)
+	require.NoError(t, mark.Observe(now))
+
+	// Moving the mark back without the key is detected
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	tampered := []byte(strings.Replace(string(data), "2026-01-01", "2025-01-01", 1))
+	require.NoError(t, os.WriteFile(path, tampered, 0o600))
+	require.ErrorIs(t, mark.Observe(now), ErrHighWaterMarkInvalid)
+
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	otherKey, err := NewHighWaterMark(HighWaterMarkOptions{Path: path, Key: []byte("fedcba9876543210fedcba9876543210")})
+	require.NoError(t, err)
+	require.ErrorIs(t, otherKey.Observe(now), ErrHighWaterMarkInvalid)
+
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	require.ErrorIs(t, mark.Observe(now), ErrHighWaterMarkInvalid)
+}
+
+func TestHighWaterMark_Deleted(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "mark")
+	mark, err := NewHighWaterMark(HighWaterMarkOptions{Path: path, Key: testHighWaterMarkKey})
+	require.NoError(t, err)
+	require.NoError(t, mark.Observe(now))
+
+	// Deleting the file once the mark was written does not reset it
+	require.NoError(t, os.Remove(path))
+	require.ErrorIs(t, mark.Observe(now.Add(-time.Hour)), ErrHighWaterMarkInvalid)
+	_, err = mark.Time()
+	require.ErrorIs(t, err, ErrHighWaterMarkInvalid)
+
+	// Nor does restarting without the file when it is required
+	required, err := NewHighWaterMark(HighWaterMarkOptions{Path: path, Key: testHighWaterMarkKey, RequireExisting: true})
+	require.NoError(t, err)
+	require.ErrorIs(t, required.Observe(now), ErrHighWaterMarkInvalid)
+
+	// The file is initialized by a mark that does not require it
+	initial, err := NewHighWaterMark(HighWaterMarkOptions{Path: path, Key: testHighWaterMarkKey})
+	require.NoError(t, err)
+	require.NoError(t, initial.Observe(now))
+	require.NoError(t, required.Observe(now))
+}
+
+func TestValidateLicenseWithResult_ClockRollback(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(24*time.Hour))
+	require.NoError(t, err)
+
+	mark, err := NewHighWaterMark(HighWaterMarkOptions{Path: filepath.Join(t.TempDir(), "mark"), Key: testHighWaterMarkKey})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	licensePath, certPath := writeLicenseFiles(t, dir, envelope.String())
+	options := ValidationOptions{
+		SkipCertificateValidation: true,
+		LicensePath:               licensePath,
+		CertPath:                  certPath,
+		OrganizationID:            "orgId",
+		ProductPlanUniqueID:       "SKU",
+		InstanceID:                "instance-1",
+		CurrentTime:               now,
+		HighWaterMark:             mark,
+	}
+	require.NoError(t, ValidateLicenseWithOptions(options))
+
+	// A clock set before the license was issued is a rollback, even on the first validation
+	options.CurrentTime = now.Add(-2 * time.Hour)
+	result := ValidateLicenseWithResult(options)
+	require.ErrorIs(t, result.Err, ErrClockRollback)
+	assert.Equal(t, ReasonClockRollback, result.Reason)
+
+	options.HighWaterMark, err = NewHighWaterMark(HighWaterMarkOptions{Path: filepath.Join(t.TempDir(), "mark"), Key: testHighWaterMarkKey})
+	require.NoError(t, err)
+	require.ErrorIs(t, ValidateLicenseWithOptions(options), ErrClockRollback)
+}
@@ -0,0 +1,185 @@
+package validator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxInstanceIDLength caps the instance IDs read from sources.
+const maxInstanceIDLength = 253
+
+// InstanceIDSource discovers the ID of the instance the product runs in. Sources return an empty ID when they
+// have none, and describe themselves with String.
+type InstanceIDSource interface {
+	InstanceID(ctx context.Context) (string, error)
+}
+
+// DiscoveredInstanceID is an instance ID and the source that supplied it.
+type DiscoveredInstanceID struct {
+	InstanceID string
+	Source     string
+}
+
+// DiscoverInstanceID asks every source for the instance ID and returns the first one found. It fails with
+// ErrInstanceIDConflict when sources disagree, and returns an empty ID when no source has one.
+func DiscoverInstanceID(ctx context.Context, sources ...InstanceIDSource) (DiscoveredInstanceID, error) {
+	var discovered DiscoveredInstanceID
+	for _, source := range sources {
+		if err := ctx.Err(); err != nil {
+			return DiscoveredInstanceID{}, err
+		}
+
+		instanceID, err := source.InstanceID(ctx)
+		if err != nil {
+			return DiscoveredInstanceID{}, errors.Wrapf(err, "failed to read instance id from %s", sourceName(source))
+		}
+		if len(instanceID) > maxInstanceIDLength {
+			return DiscoveredInstanceID{}, fmt.Errorf("instance id from %s exceeds %d characters", sourceName(source), maxInstanceIDLength)
+		}
+
+		switch {
+		case instanceID == "":
+		case discovered.InstanceID == "":
+			discovered = DiscoveredInstanceID{InstanceID: instanceID, Source: sourceName(source)}
+		case discovered.InstanceID != instanceID:
+			return DiscoveredInstanceID{}, fmt.Errorf("%w: %s from %s, %s from %s",
+				ErrInstanceIDConflict, discovered.InstanceID, discovered.Source, instanceID, sourceName(source))
+		}
+	}
+	return discovered, nil
+}
+
+func sourceName(source any) string {
+	if stringer, ok := source.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%T", source)
+}
+
+type envInstanceIDSource struct {
+	name string
+}
+
+// NewEnvInstanceIDSource reads the instance ID from an environment variable, such as the INSTANCE_ID variable
+// set by Omnistrate.
+func NewEnvInstanceIDSource(name string) InstanceIDSource {
+	return &envInstanceIDSource{name: name}
+}
+
+func (s *envInstanceIDSource) InstanceID(ctx context.Context) (string, error) {
+	return strings.TrimSpace(os.Getenv(s.name)), nil
+}
+
+func (s *envInstanceIDSource) String() string {
+	return "env " + s.name
+}
+
+type fileInstanceIDSource struct {
+	path string
+}
+
+// NewFileInstanceIDSource reads the instance ID from a file, such as a Downward API file exposing a single
+// label. A missing file has no instance ID.
+func NewFileInstanceIDSource(path string) InstanceIDSource {
+	return &fileInstanceIDSource{path: path}
+}
+
+func (s *fileInstanceIDSource) InstanceID(ctx context.Context) (string, error) {
+	data, err := readInstanceIDFile(s.path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (s *fileInstanceIDSource) String() string {
+	return "file " + s.path
+}
+
+type downwardAPIInstanceIDSource struct {
+	path string
+	key  string
+}
+
+// NewDownwardAPIInstanceIDSource reads the instance ID from a label or annotation of the pod, in a Downward API
+// file exposing metadata.labels or metadata.annotations. A missing file or key has no instance ID.
+func NewDownwardAPIInstanceIDSource(path, key string) InstanceIDSource {
+	return &downwardAPIInstanceIDSource{path: path, key: key}
+}
+
+func (s *downwardAPIInstanceIDSource) InstanceID(ctx context.Context) (string, error) {
+	data, err := readInstanceIDFile(s.path)
+	if err != nil {
+		return "", err
+	}
+
+	// Each line is key="value", with the value quoted as a Go string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || key != s.key {
+			continue
+		}
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid value for %s", s.key)
+		}
+		return strings.TrimSpace(unquoted), nil
+	}
+	return "", scanner.Err()
+}
+
+func (s *downwardAPIInstanceIDSource) String() string {
+	return fmt.Sprintf("downward api %s[%s]", s.path, s.key)
+}
+
+type namespaceInstanceIDSource struct {
+	path   string
+	prefix string
+}
+
+// NewNamespaceInstanceIDSource uses the Kubernetes namespace of the pod as the instance ID when it starts with
+// the prefix, for deployments that name namespaces after their instance. The namespace is read from path, which
+// defaults to the service account namespace file.
+func NewNamespaceInstanceIDSource(path, prefix string) InstanceIDSource {
+	if path == "" {
+		path = defaultNamespacePath
+	}
+	return &namespaceInstanceIDSource{path: path, prefix: prefix}
+}
+
+func (s *namespaceInstanceIDSource) InstanceID(ctx context.Context) (string, error) {
+	data, err := readInstanceIDFile(s.path)
+	if err != nil {
+		return "", err
+	}
+	namespace := strings.TrimSpace(string(data))
+	if namespace == "" || !strings.HasPrefix(namespace, s.prefix) {
+		return "", nil
+	}
+	return namespace, nil
+}
+
+func (s *namespaceInstanceIDSource) String() string {
+	return "namespace " + s.path
+}
+
+// readInstanceIDFile reads a small file, which has no content when missing.
+func readInstanceIDFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readLimited(file)
+}
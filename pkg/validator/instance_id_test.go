@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverInstanceID(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	labelsPath := writeFile("labels", "app=\"api\"\nomnistrate.com/instance-id=\"instance-1\"\n")
+	namespacePath := writeFile("namespace", "instance-1\n")
+	filePath := writeFile("instance-id", "instance-1\n")
+	missingPath := filepath.Join(dir, "missing")
+
+	// Sources without an instance ID are skipped
+	discovered, err := DiscoverInstanceID(ctx,
+		NewFileInstanceIDSource(missingPath),
+		NewDownwardAPIInstanceIDSource(labelsPath, "omnistrate.com/instance-id"),
+		NewNamespaceInstanceIDSource(namespacePath, "instance-"),
+		NewFileInstanceIDSource(filePath),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, DiscoveredInstanceID{InstanceID: "instance-1", Source: "downward api " + labelsPath + "[omnistrate.com/instance-id]"}, discovered)
+
+	discovered, err = DiscoverInstanceID(ctx, NewNamespaceInstanceIDSource(namespacePath, "tenant-"), NewDownwardAPIInstanceIDSource(labelsPath, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, discovered.InstanceID)
+
+	// Sources that disagree fail, naming both sources
+	_, err = DiscoverInstanceID(ctx, NewFileInstanceIDSource(filePath), NewFileInstanceIDSource(writeFile("other", "instance-2")))
+	require.ErrorIs(t, err, ErrInstanceIDConflict)
+	assert.Contains(t, err.Error(), "instance-1 from file "+filePath)
+	assert.Contains(t, err.Error(), "instance-2 from file "+filepath.Join(dir, "other"))
+
+	_, err = DiscoverInstanceID(ctx, NewFileInstanceIDSource(writeFile("long", strings.Repeat("a", maxInstanceIDLength+1))))
+	require.Error(t, err)
+
+	_, err = DiscoverInstanceID(ctx, NewDownwardAPIInstanceIDSource(writeFile("malformed", "omnistrate.com/instance-id=instance-1"), "omnistrate.com/instance-id"))
+	require.Error(t, err)
+}
+
+func TestValidateLicenseWithOptions_InstanceIDSources(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	instancePath := filepath.Join(dir, "instance-id")
+	require.NoError(t, os.WriteFile(instancePath, []byte("instance-1"), 0o600))
+
+	options := ValidationOptions{
+		SkipCertificateValidation: true,
+		LicenseSource:             NewBytesSource([]byte(envelope.String())),
+		CertSource:                NewBytesSource(certPEM),
+		OrganizationID:            "orgId",
+		ProductPlanUniqueID:       "SKU",
+		InstanceIDSources:         []InstanceIDSource{NewFileInstanceIDSource(instancePath)},
+	}
+	result := ValidateLicenseWithResult(options)
+	require.NoError(t, result.Err)
+	assert.Equal(t, DiscoveredInstanceID{InstanceID: "instance-1", Source: "file " + instancePath}, result.InstanceID)
+
+	namespacePath := filepath.Join(dir, "namespace")
+	require.NoError(t, os.WriteFile(namespacePath, []byte("instance-2"), 0o600))
+	options.InstanceIDSources = append(options.InstanceIDSources, NewNamespaceInstanceIDSource(namespacePath, "instance-"))
+	result = ValidateLicenseWithResult(options)
+	require.ErrorIs(t, result.Err, ErrInstanceIDConflict)
+	assert.Equal(t, ReasonMismatch, result.Reason)
+
+	// An instance ID set in code takes precedence over discovery
+	options.InstanceID = "instance-1"
+	result = ValidateLicenseWithResult(options)
+	require.NoError(t, result.Err)
+	assert.Equal(t, "options", result.InstanceID.Source)
+}
@@ -0,0 +1,217 @@
+// Package kubesource reads the license and certificate from a Kubernetes Secret through the API,
+// instead of mounting the Secret as a volume.
+package kubesource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	DefaultSecretName = "service-plan-subscription-license"
+	DefaultLicenseKey = "license.lic"
+	DefaultCertKey    = "license.crt"
+
+	podNamespaceEnv        = "POD_NAMESPACE"
+	serviceAccountNSPath   = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	secretNameFieldElement = "metadata.name"
+)
+
+type Options struct {
+	// Client defaults to a client built from the in-cluster configuration.
+	Client kubernetes.Interface
+	// Namespace defaults to the POD_NAMESPACE environment variable, then to the namespace of the service account.
+	Namespace string
+	// Name defaults to DefaultSecretName.
+	Name       string
+	LicenseKey string
+	CertKey    string
+}
+
+// SecretSource reads the license and certificate from the keys of a single Secret. Once watched,
+// both are served from the latest version of the Secret, so they always come from the same update.
+type SecretSource struct {
+	client     kubernetes.Interface
+	namespace  string
+	name       string
+	licenseKey string
+	certKey    string
+
+	mu       sync.RWMutex
+	watching bool
+	secret   *corev1.Secret
+}
+
+func NewSecretSource(options Options) (*SecretSource, error) {
+	if options.Client == nil {
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load in-cluster configuration")
+		}
+		options.Client, err = kubernetes.NewForConfig(config)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create kubernetes client")
+		}
+	}
+
+	if options.Namespace == "" {
+		options.Namespace = currentNamespace()
+	}
+	if options.Namespace == "" {
+		return nil, fmt.Errorf("secret namespace is required outside of a pod")
+	}
+
+	if options.Name == "" {
+		options.Name = DefaultSecretName
+	}
+	if options.LicenseKey == "" {
+		options.LicenseKey = DefaultLicenseKey
+	}
+	if options.CertKey == "" {
+		options.CertKey = DefaultCertKey
+	}
+
+	return &SecretSource{
+		client:     options.Client,
+		namespace:  options.Namespace,
+		name:       options.Name,
+		licenseKey: options.LicenseKey,
+		certKey:    options.CertKey,
+	}, nil
+}
+
+// License returns the source of the license. It is a validator.WatchableSource, and watching it
+// also covers certificate updates since both come from the same Secret.
+func (s *SecretSource) License() validator.LicenseSource {
+	return &licenseKeySource{keySource{secret: s, key: s.licenseKey}}
+}
+
+func (s *SecretSource) Certificate() validator.LicenseSource {
+	return &keySource{secret: s, key: s.certKey}
+}
+
+// ValidationOptions returns the options with the license and certificate read from the Secret.
+func (s *SecretSource) ValidationOptions(options validator.ValidationOptions) validator.ValidationOptions {
+	options.LicenseSource = s.License()
+	options.CertSource = s.Certificate()
+	return options
+}
+
+// Watch keeps the latest version of the Secret cached and calls changed on every update until the context is done.
+func (s *SecretSource) Watch(ctx context.Context, changed func()) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(s.client, 0,
+		informers.WithNamespace(s.namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector(secretNameFieldElement, s.name).String()
+		}),
+	)
+	informer := factory.Core().V1().Secrets().Informer()
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			s.update(obj, changed)
+		},
+		UpdateFunc: func(_, obj any) {
+			s.update(obj, changed)
+		},
+		DeleteFunc: func(any) {
+			s.update(nil, changed)
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch secret")
+	}
+
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return ctx.Err()
+	}
+
+	s.mu.Lock()
+	s.watching = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.watching = false
+		s.secret = nil
+		s.mu.Unlock()
+	}()
+
+	<-ctx.Done()
+	return nil
+}
+
+func (s *SecretSource) update(obj any, changed func()) {
+	secret, _ := obj.(*corev1.Secret)
+
+	s.mu.Lock()
+	previous := s.secret
+	s.secret = secret
+	s.mu.Unlock()
+
+	if previous != nil && secret != nil && previous.ResourceVersion == secret.ResourceVersion {
+		return
+	}
+	changed()
+}
+
+func (s *SecretSource) load(ctx context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	secret, watching := s.secret, s.watching
+	s.mu.RUnlock()
+
+	if !watching || secret == nil {
+		var err error
+		secret, err = s.client.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get secret %s/%s", s.namespace, s.name)
+		}
+	}
+
+	data, ok := secret.Data[key]
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %q key", s.namespace, s.name, key)
+	}
+	return data, nil
+}
+
+type keySource struct {
+	secret *SecretSource
+	key    string
+}
+
+func (k *keySource) Load(ctx context.Context) ([]byte, error) {
+	return k.secret.load(ctx, k.key)
+}
+
+type licenseKeySource struct {
+	keySource
+}
+
+func (k *licenseKeySource) Watch(ctx context.Context, changed func()) error {
+	return k.secret.Watch(ctx, changed)
+}
+
+func currentNamespace() string {
+	if namespace := os.Getenv(podNamespaceEnv); namespace != "" {
+		return namespace
+	}
+	namespace, err := os.ReadFile(serviceAccountNSPath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(namespace))
+}
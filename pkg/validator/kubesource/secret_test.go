@@ -0,0 +1,137 @@
+package kubesource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/internal/testcert"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var keyPEM, certPEM = testcert.New()
+
+func newLicenseSecret(envelope *common.LicenseEnvelope) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultSecretName,
+			Namespace: "tenant",
+		},
+		Data: map[string][]byte{
+			DefaultLicenseKey: []byte(envelope.String()),
+			DefaultCertKey:    certPEM,
+		},
+	}
+}
+
+func TestSecretSource(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	client := fake.NewClientset(newLicenseSecret(envelope))
+	source, err := NewSecretSource(Options{Client: client, Namespace: "tenant"})
+	require.NoError(t, err)
+
+	license, err := source.License().Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, envelope.String(), string(license))
+
+	cert, err := source.Certificate().Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, certPEM, cert)
+
+	err = validator.ValidateLicenseWithOptions(source.ValidationOptions(validator.ValidationOptions{
+		SkipCertificateValidation: true,
+		OrganizationID:            "orgId",
+		ProductPlanUniqueID:       "SKU",
+		InstanceID:                "instance-1",
+	}))
+	require.NoError(t, err)
+}
+
+func TestSecretSource_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: "tenant"},
+		Data:       map[string][]byte{"license": []byte("{}")},
+	})
+
+	source, err := NewSecretSource(Options{Client: client, Namespace: "tenant"})
+	require.NoError(t, err)
+	_, err = source.License().Load(ctx)
+	require.ErrorContains(t, err, "failed to get secret tenant/service-plan-subscription-license")
+
+	source, err = NewSecretSource(Options{Client: client, Namespace: "tenant", Name: "custom", LicenseKey: "license"})
+	require.NoError(t, err)
+	_, err = source.License().Load(ctx)
+	require.NoError(t, err)
+	_, err = source.Certificate().Load(ctx)
+	require.ErrorContains(t, err, `has no "license.crt" key`)
+}
+
+func TestSecretSource_Watch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(48*time.Hour))
+	require.NoError(t, err)
+
+	client := fake.NewClientset(newLicenseSecret(envelope))
+	source, err := NewSecretSource(Options{Client: client, Namespace: "tenant"})
+	require.NoError(t, err)
+
+	renewed := make(chan *validator.LicenseStatus, 10)
+	invalid := make(chan *validator.LicenseStatus, 10)
+	watcher := validator.NewWatcher(validator.WatcherOptions{
+		ValidationOptions: source.ValidationOptions(validator.ValidationOptions{
+			SkipCertificateValidation: true,
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+		}),
+		Interval:     time.Hour,
+		PollInterval: time.Hour,
+		OnRenewed:    func(previous, current *validator.LicenseStatus) { renewed <- current },
+		OnInvalid:    func(status *validator.LicenseStatus) { invalid <- status },
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.True(t, watcher.Start(ctx).IsValid())
+
+	// Updating the secret re-validates the license without waiting for the interval
+	renewedEnvelope, err := manager.RenewLicense(envelope, now.Add(96*time.Hour))
+	require.NoError(t, err)
+	_, err = client.CoreV1().Secrets("tenant").Update(ctx, newLicenseSecret(renewedEnvelope), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	select {
+	case status := <-renewed:
+		assert.Equal(t, renewedEnvelope.License.ID, status.License().ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("renewal was not detected")
+	}
+
+	require.NoError(t, client.CoreV1().Secrets("tenant").Delete(ctx, DefaultSecretName, metav1.DeleteOptions{}))
+	select {
+	case status := <-invalid:
+		require.Error(t, status.Err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("deletion was not detected")
+	}
+}
@@ -0,0 +1,177 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+)
+
+const (
+	RejectionCodeLicenseInvalid  = "license_invalid"
+	RejectionCodeFeatureRequired = "license_feature_required"
+	RejectionCodePlanRequired    = "license_plan_required"
+)
+
+// StatusProvider exposes the latest license status, such as a Watcher.
+type StatusProvider interface {
+	Status() *LicenseStatus
+}
+
+type StatusProviderFunc func() *LicenseStatus
+
+func (f StatusProviderFunc) Status() *LicenseStatus {
+	return f()
+}
+
+// Rejection describes why a request was rejected. It is written as the JSON error of the response by default.
+type Rejection struct {
+	Code    string `json:"code"`
+	Reason  Reason `json:"reason,omitempty"`
+	Message string `json:"message"`
+}
+
+type MiddlewareOption func(*Middleware)
+
+// WithInvalidLicenseStatus sets the status of responses to requests rejected because the license is invalid
+// or expired. Defaults to 403.
+func WithInvalidLicenseStatus(statusCode int) MiddlewareOption {
+	return func(m *Middleware) {
+		m.invalidStatus = statusCode
+	}
+}
+
+// WithForbiddenStatus sets the status of responses to requests rejected because the license lacks a required
+// feature or plan. Defaults to 403.
+func WithForbiddenStatus(statusCode int) MiddlewareOption {
+	return func(m *Middleware) {
+		m.forbiddenStatus = statusCode
+	}
+}
+
+// WithRejectionBody replaces the JSON body of rejected responses, which defaults to {"error": rejection}.
+func WithRejectionBody(body func(r *http.Request, rejection Rejection) any) MiddlewareOption {
+	return func(m *Middleware) {
+		m.body = body
+	}
+}
+
+// Middleware gates HTTP handlers on the license status reported by a provider, usually a Watcher, so requests
+// never re-validate the license themselves.
+type Middleware struct {
+	provider        StatusProvider
+	invalidStatus   int
+	forbiddenStatus int
+	body            func(r *http.Request, rejection Rejection) any
+}
+
+func NewMiddleware(provider StatusProvider, opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{
+		provider:        provider,
+		invalidStatus:   http.StatusForbidden,
+		forbiddenStatus: http.StatusForbidden,
+		body: func(_ *http.Request, rejection Rejection) any {
+			return map[string]Rejection{"error": rejection}
+		},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Handler rejects requests while the license is invalid or expired, and otherwise adds the license status
+// to the request context.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return m.gate(next, nil)
+}
+
+// RequireFeatures returns a middleware that also rejects requests when the license lacks any of the features.
+func (m *Middleware) RequireFeatures(features ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return m.gate(next, func(license *common.License) *Rejection {
+			for _, feature := range features {
+				if !license.HasFeature(feature) {
+					return &Rejection{Code: RejectionCodeFeatureRequired, Message: "license does not include feature " + feature}
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// RequirePlans returns a middleware that also rejects requests when the license is for none of the plans.
+func (m *Middleware) RequirePlans(productPlanUniqueIDs ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return m.gate(next, func(license *common.License) *Rejection {
+			if !slices.Contains(productPlanUniqueIDs, license.ProductPlanUniqueID) {
+				return &Rejection{Code: RejectionCodePlanRequired, Message: "license is not for a permitted plan"}
+			}
+			return nil
+		})
+	}
+}
+
+func (m *Middleware) gate(next http.Handler, check func(license *common.License) *Rejection) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := m.provider.Status()
+		enforced := status.IsEnforced()
+
+		if !status.IsValid() && enforced {
+			// The validation error is not exposed to clients
+			m.reject(w, r, m.invalidStatus, Rejection{Code: RejectionCodeLicenseInvalid, Reason: licenseStatusReason(status), Message: "license is not valid"})
+			return
+		}
+
+		if check != nil && enforced {
+			license := status.License()
+			if license == nil {
+				m.reject(w, r, m.invalidStatus, Rejection{Code: RejectionCodeLicenseInvalid, Reason: licenseStatusReason(status), Message: "license is not valid"})
+				return
+			}
+			if rejection := check(license); rejection != nil {
+				m.reject(w, r, m.forbiddenStatus, *rejection)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(ContextWithLicenseStatus(r.Context(), status)))
+	})
+}
+
+func (m *Middleware) reject(w http.ResponseWriter, r *http.Request, statusCode int, rejection Rejection) {
+	writeJSON(w, statusCode, m.body(r, rejection))
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func licenseStatusReason(status *LicenseStatus) Reason {
+	if status == nil || status.Reason == "" {
+		return ReasonUnavailable
+	}
+	return status.Reason
+}
+
+type licenseStatusContextKey struct{}
+
+func ContextWithLicenseStatus(ctx context.Context, status *LicenseStatus) context.Context {
+	return context.WithValue(ctx, licenseStatusContextKey{}, status)
+}
+
+// LicenseStatusFromContext returns the license status added by a Middleware, or nil.
+func LicenseStatusFromContext(ctx context.Context) *LicenseStatus {
+	status, _ := ctx.Value(licenseStatusContextKey{}).(*LicenseStatus)
+	return status
+}
+
+// LicenseFromContext returns the validated license added by a Middleware, or nil. The license may be invalid when
+// the middleware does not enforce it, which LicenseStatusFromContext reports.
+func LicenseFromContext(ctx context.Context) *common.License {
+	return LicenseStatusFromContext(ctx).License()
+}
@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveMiddleware(handler http.Handler) (*httptest.ResponseRecorder, map[string]Rejection) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	var body map[string]Rejection
+	_ = json.Unmarshal(recorder.Body.Bytes(), &body)
+	return recorder, body
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+	envelope, err := manager.GenerateLicenseFromRequest(generator.NewLicenseRequest("orgId", "SKU", now.Add(time.Hour),
+		generator.WithInstanceID("instance-1"),
+		generator.WithFeatures("sso"),
+	))
+	require.NoError(t, err)
+
+	watcher := NewWatcher(WatcherOptions{
+		ValidationOptions: ValidationOptions{
+			SkipCertificateValidation: true,
+			LicenseSource:             NewBytesSource([]byte(envelope.String())),
+			CertSource:                NewBytesSource(certPEM),
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+		},
+	})
+	middleware := NewMiddleware(watcher)
+
+	var licenseID string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		licenseID = LicenseFromContext(r.Context()).ID
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Requests fail closed until the watcher has validated the license
+	recorder, body := serveMiddleware(middleware.Handler(next))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, RejectionCodeLicenseInvalid, body["error"].Code)
+	assert.Equal(t, ReasonUnavailable, body["error"].Reason)
+
+	watcher.Check()
+	recorder, _ = serveMiddleware(middleware.Handler(next))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, envelope.License.ID, licenseID)
+
+	recorder, _ = serveMiddleware(middleware.RequireFeatures("sso")(next))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	recorder, body = serveMiddleware(middleware.RequireFeatures("sso", "audit-log")(next))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Equal(t, RejectionCodeFeatureRequired, body["error"].Code)
+
+	recorder, _ = serveMiddleware(middleware.RequirePlans("OTHER", "SKU")(next))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	recorder, body = serveMiddleware(middleware.RequirePlans("OTHER")(next))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Equal(t, RejectionCodePlanRequired, body["error"].Code)
+}
+
+func TestMiddleware_InvalidLicense(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(-time.Hour))
+	require.NoError(t, err)
+
+	status := &LicenseStatus{State: LicenseStateExpired, Reason: ReasonExpired, Envelope: envelope, Err: ErrLicenseExpired, Verified: true}
+	provider := StatusProviderFunc(func() *LicenseStatus { return status })
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	middleware := NewMiddleware(provider,
+		WithInvalidLicenseStatus(http.StatusPaymentRequired),
+		WithForbiddenStatus(http.StatusNotFound),
+	)
+	recorder, body := serveMiddleware(middleware.Handler(next))
+	assert.Equal(t, http.StatusPaymentRequired, recorder.Code)
+	assert.Equal(t, ReasonExpired, body["error"].Reason)
+	assert.NotContains(t, recorder.Body.String(), ErrLicenseExpired.Error())
+
+	recorder, _ = serveMiddleware(middleware.RequireFeatures("sso")(next))
+	assert.Equal(t, http.StatusPaymentRequired, recorder.Code)
+
+	custom := NewMiddleware(provider, WithRejectionBody(func(_ *http.Request, rejection Rejection) any {
+		return map[string]string{"message": rejection.Message}
+	}))
+	recorder, _ = serveMiddleware(custom.Handler(next))
+	assert.JSONEq(t, `{"message":"license is not valid"}`, recorder.Body.String())
+
+	// Warn and audit modes let requests through
+	status.Mode = EnforcementModeWarn
+	recorder, _ = serveMiddleware(middleware.RequireFeatures("sso")(next))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
@@ -0,0 +1,167 @@
+package validator
+
+import (
+	"math"
+	"net/http"
+	"time"
+)
+
+// reasonMessages explains the reasons of a failed validation to probe clients, without exposing the validation error.
+var reasonMessages = map[Reason]string{
+	ReasonValid:              "license is valid",
+	ReasonUnavailable:        "license could not be read",
+	ReasonExpired:            "license has expired",
+	ReasonNotYetValid:        "license is not valid yet",
+	ReasonMismatch:           "license does not match this deployment",
+	ReasonInvalidSignature:   "license signature is invalid",
+	ReasonInvalidCertificate: "license signing certificate is invalid",
+	ReasonClockRollback:      "system clock was rolled back",
+	ReasonUntrustedTime:      "current time could not be trusted",
+	ReasonInvalidTimestamp:   "license timestamp is invalid",
+	ReasonInvalid:            "license is not valid",
+}
+
+// ProbeResponse is the body of the readiness and liveness handlers.
+type ProbeResponse struct {
+	OK        bool            `json:"ok"`
+	State     LicenseState    `json:"state"`
+	Reason    Reason          `json:"reason"`
+	Mode      EnforcementMode `json:"mode,omitempty"`
+	Message   string          `json:"message"`
+	CheckedAt *time.Time      `json:"checkedAt,omitempty"`
+}
+
+// LicenseSummary is the body of the license summary handler.
+type LicenseSummary struct {
+	LicenseID            string       `json:"licenseId"`
+	OrganizationID       string       `json:"organizationId"`
+	ProductPlanUniqueID  string       `json:"productPlanUniqueId"`
+	State                LicenseState `json:"state"`
+	Reason               Reason       `json:"reason"`
+	ExpiresAt            time.Time    `json:"expiresAt"`
+	DaysRemaining        int          `json:"daysRemaining"`
+	CertificateExpiresAt *time.Time   `json:"certificateExpiresAt,omitempty"`
+	CheckedAt            time.Time    `json:"checkedAt"`
+}
+
+type ProbeOption func(*probe)
+
+// WithStaleAfter fails the liveness probe when the latest validation is older than the duration, which happens
+// when the watcher stopped. Set it to a few watcher intervals. Disabled by default.
+func WithStaleAfter(staleAfter time.Duration) ProbeOption {
+	return func(p *probe) {
+		p.staleAfter = staleAfter
+	}
+}
+
+// WithProbeClock reads the current time from now. Defaults to time.Now.
+func WithProbeClock(now func() time.Time) ProbeOption {
+	return func(p *probe) {
+		p.now = now
+	}
+}
+
+type probe struct {
+	provider   StatusProvider
+	staleAfter time.Duration
+	now        func() time.Time
+}
+
+func newProbe(provider StatusProvider, opts []ProbeOption) *probe {
+	p := &probe{
+		provider: provider,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// NewReadinessHandler reports ready while the license is valid, and not ready with a 503 while it is expired,
+// invalid or not checked yet. Licenses are never reported not ready in warn or audit mode.
+func NewReadinessHandler(provider StatusProvider, opts ...ProbeOption) http.Handler {
+	p := newProbe(provider, opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := p.provider.Status()
+		response := newProbeResponse(status)
+		response.OK = status.IsValid() || !status.IsEnforced()
+		writeProbeResponse(w, response)
+	})
+}
+
+// NewLivenessHandler reports live unless the latest validation is stale, see WithStaleAfter. An invalid license
+// does not fail liveness, since restarting the pod would not fix it.
+func NewLivenessHandler(provider StatusProvider, opts ...ProbeOption) http.Handler {
+	p := newProbe(provider, opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := p.provider.Status()
+		response := newProbeResponse(status)
+		response.OK = true
+		if p.staleAfter > 0 && status != nil && !status.CheckedAt.IsZero() && p.now().Sub(status.CheckedAt) > p.staleAfter {
+			response.OK = false
+			response.Message = "license has not been validated since " + status.CheckedAt.UTC().Format(time.RFC3339)
+		}
+		writeProbeResponse(w, response)
+	})
+}
+
+// NewLicenseSummaryHandler returns the summary of the latest validated license, or a 503 when the license
+// could not be read or its signature was not verified.
+func NewLicenseSummaryHandler(provider StatusProvider, opts ...ProbeOption) http.Handler {
+	p := newProbe(provider, opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := p.provider.Status()
+		license := status.License()
+		if license == nil {
+			reason := licenseStatusReason(status)
+			writeJSON(w, http.StatusServiceUnavailable, map[string]Rejection{
+				"error": {Code: RejectionCodeLicenseInvalid, Reason: reason, Message: reasonMessages[reason]},
+			})
+			return
+		}
+
+		expiresAt := status.ExpiresAt()
+		summary := LicenseSummary{
+			LicenseID:           license.ID,
+			OrganizationID:      license.OrganizationID,
+			ProductPlanUniqueID: license.ProductPlanUniqueID,
+			State:               status.State,
+			Reason:              status.Reason,
+			ExpiresAt:           expiresAt,
+			DaysRemaining:       int(math.Floor(expiresAt.Sub(p.now()).Hours() / 24)),
+			CheckedAt:           status.CheckedAt,
+		}
+		if !status.CertificateExpiresAt.IsZero() {
+			summary.CertificateExpiresAt = &status.CertificateExpiresAt
+		}
+		writeJSON(w, http.StatusOK, summary)
+	})
+}
+
+func newProbeResponse(status *LicenseStatus) ProbeResponse {
+	response := ProbeResponse{
+		State:  LicenseStateUnknown,
+		Reason: licenseStatusReason(status),
+	}
+	if status != nil {
+		response.State = status.State
+		response.Mode = status.Mode
+		if !status.CheckedAt.IsZero() {
+			response.CheckedAt = &status.CheckedAt
+		}
+	}
+	response.Message = reasonMessages[response.Reason]
+	if response.State == LicenseStateUnknown {
+		response.Message = "license has not been validated yet"
+	}
+	return response
+}
+
+func writeProbeResponse(w http.ResponseWriter, response ProbeResponse) {
+	statusCode := http.StatusOK
+	if !response.OK {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeJSON(w, statusCode, response)
+}
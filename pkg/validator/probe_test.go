@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveProbe[T any](t *testing.T, handler http.Handler) (int, T) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var body T
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	return recorder.Code, body
+}
+
+func TestProbeHandlers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(10*24*time.Hour+time.Hour))
+	require.NoError(t, err)
+
+	watcher := NewWatcher(WatcherOptions{
+		ValidationOptions: ValidationOptions{
+			SkipCertificateValidation: true,
+			LicenseSource:             NewBytesSource([]byte(envelope.String())),
+			CertSource:                NewBytesSource(certPEM),
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+			CurrentTime:               now,
+		},
+	})
+	clock := WithProbeClock(func() time.Time { return now })
+	readiness := NewReadinessHandler(watcher, clock)
+	summaryHandler := NewLicenseSummaryHandler(watcher, clock)
+
+	// Not ready before the first validation
+	code, response := serveProbe[ProbeResponse](t, readiness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, response.OK)
+	assert.Equal(t, LicenseStateUnknown, response.State)
+	code, rejection := serveProbe[map[string]Rejection](t, summaryHandler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, ReasonUnavailable, rejection["error"].Reason)
+
+	watcher.Check()
+	code, response = serveProbe[ProbeResponse](t, readiness)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, response.OK)
+	assert.Equal(t, LicenseStateValid, response.State)
+	assert.Equal(t, ReasonValid, response.Reason)
+
+	code, summary := serveProbe[LicenseSummary](t, summaryHandler)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, envelope.License.ID, summary.LicenseID)
+	assert.Equal(t, "SKU", summary.ProductPlanUniqueID)
+	assert.Equal(t, 10, summary.DaysRemaining)
+	assert.Equal(t, watcher.Status().ExpiresAt(), summary.ExpiresAt)
+	require.NotNil(t, summary.CertificateExpiresAt)
+	assert.Equal(t, watcher.Status().CertificateExpiresAt, *summary.CertificateExpiresAt)
+}
+
+func TestProbeHandlers_InvalidLicense(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(-36*time.Hour))
+	require.NoError(t, err)
+
+	status := &LicenseStatus{State: LicenseStateExpired, Reason: ReasonExpired, Envelope: envelope, Err: ErrLicenseExpired, CheckedAt: now, Verified: true}
+	provider := StatusProviderFunc(func() *LicenseStatus { return status })
+	clock := WithProbeClock(func() time.Time { return now })
+
+	code, response := serveProbe[ProbeResponse](t, NewReadinessHandler(provider, clock))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, ReasonExpired, response.Reason)
+	assert.Equal(t, "license has expired", response.Message)
+
+	// The summary still describes an expired license
+	code, summary := serveProbe[LicenseSummary](t, NewLicenseSummaryHandler(provider, clock))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, -2, summary.DaysRemaining)
+	assert.Nil(t, summary.CertificateExpiresAt)
+
+	// The claims of a license whose signature was not verified are never described
+	unverified := &LicenseStatus{State: LicenseStateInvalid, Reason: ReasonInvalidSignature, Envelope: envelope, CheckedAt: now}
+	code, rejection := serveProbe[map[string]Rejection](t, NewLicenseSummaryHandler(StatusProviderFunc(func() *LicenseStatus { return unverified }), clock))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, ReasonInvalidSignature, rejection["error"].Reason)
+
+	// An invalid license does not fail liveness, a stopped watcher does
+	liveness := NewLivenessHandler(provider, clock, WithStaleAfter(time.Hour))
+	code, response = serveProbe[ProbeResponse](t, liveness)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, response.OK)
+
+	status.CheckedAt = now.Add(-2 * time.Hour)
+	code, response = serveProbe[ProbeResponse](t, liveness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, response.OK)
+
+	// Warn mode keeps the pod ready
+	status.Mode = EnforcementModeWarn
+	code, response = serveProbe[ProbeResponse](t, NewReadinessHandler(provider, clock))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, ReasonExpired, response.Reason)
+}
@@ -0,0 +1,153 @@
+// Package promcollector exposes the license status reported by a validator.Watcher as Prometheus metrics.
+package promcollector
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultNamespace = "license"
+
+// unknownLabel is the value of the labels not set in Options while the license signature is not verified.
+const unknownLabel = "unknown"
+
+var labelNames = []string{"org", "plan", "instance"}
+
+// Options configures a Collector. The org, plan and instance labels are taken from the validated license,
+// unless set here. They are "unknown" while the license could not be read or its signature was not verified.
+type Options struct {
+	// Namespace prefixes the metric names. Defaults to "license".
+	Namespace string
+
+	OrganizationID      string
+	ProductPlanUniqueID string
+	InstanceID          string
+
+	// Now returns the current time, used for the remaining time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Collector is a prometheus.Collector fed with every watcher check through Observe.
+type Collector struct {
+	options Options
+
+	expiryDesc            *prometheus.Desc
+	remainingDesc         *prometheus.Desc
+	certificateExpiryDesc *prometheus.Desc
+	lastSuccessDesc       *prometheus.Desc
+	validations           *prometheus.CounterVec
+
+	mu          sync.Mutex
+	labels      []string
+	status      *validator.LicenseStatus
+	lastSuccess time.Time
+}
+
+func NewCollector(options Options) *Collector {
+	if options.Namespace == "" {
+		options.Namespace = defaultNamespace
+	}
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+
+	return &Collector{
+		options: options,
+		expiryDesc: prometheus.NewDesc(prometheus.BuildFQName(options.Namespace, "", "expiry_timestamp_seconds"),
+			"Expiration time of the license, in seconds since the epoch.", labelNames, nil),
+		remainingDesc: prometheus.NewDesc(prometheus.BuildFQName(options.Namespace, "", "remaining_seconds"),
+			"Seconds until the license expires, negative once expired.", labelNames, nil),
+		certificateExpiryDesc: prometheus.NewDesc(prometheus.BuildFQName(options.Namespace, "certificate", "expiry_timestamp_seconds"),
+			"Expiration time of the license signing certificate, in seconds since the epoch.", labelNames, nil),
+		lastSuccessDesc: prometheus.NewDesc(prometheus.BuildFQName(options.Namespace, "", "last_successful_validation_timestamp_seconds"),
+			"Time of the last successful license validation, in seconds since the epoch.", labelNames, nil),
+		validations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: options.Namespace,
+			Name:      "validations_total",
+			Help:      "License validations by result and reason.",
+		}, slices.Concat(labelNames, []string{"result", "reason"})),
+		labels: unknownLabels(options),
+	}
+}
+
+// Observe records the outcome of a license check. It is meant to be set as WatcherOptions.OnCheck.
+func (c *Collector) Observe(status *validator.LicenseStatus) {
+	if status == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status = status
+	// Claims of unverified licenses are never used as labels
+	c.labels = unknownLabels(c.options)
+	if license := status.License(); license != nil {
+		c.labels = []string{
+			firstNonEmpty(c.options.OrganizationID, license.OrganizationID, unknownLabel),
+			firstNonEmpty(c.options.ProductPlanUniqueID, license.ProductPlanUniqueID, unknownLabel),
+			firstNonEmpty(c.options.InstanceID, license.InstanceID, unknownLabel),
+		}
+	}
+
+	result := "failure"
+	if status.IsValid() {
+		result = "success"
+		c.lastSuccess = status.CheckedAt
+	}
+	c.validations.WithLabelValues(slices.Concat(c.labels, []string{result, string(status.Reason)})...).Inc()
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.expiryDesc
+	ch <- c.remainingDesc
+	ch <- c.certificateExpiryDesc
+	ch <- c.lastSuccessDesc
+	c.validations.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.validations.Collect(ch)
+	if c.status == nil {
+		return
+	}
+
+	if expiresAt := c.status.ExpiresAt(); !expiresAt.IsZero() {
+		ch <- prometheus.MustNewConstMetric(c.expiryDesc, prometheus.GaugeValue, unixSeconds(expiresAt), c.labels...)
+		ch <- prometheus.MustNewConstMetric(c.remainingDesc, prometheus.GaugeValue, expiresAt.Sub(c.options.Now()).Seconds(), c.labels...)
+	}
+	if !c.status.CertificateExpiresAt.IsZero() {
+		ch <- prometheus.MustNewConstMetric(c.certificateExpiryDesc, prometheus.GaugeValue, unixSeconds(c.status.CertificateExpiresAt), c.labels...)
+	}
+	if !c.lastSuccess.IsZero() {
+		ch <- prometheus.MustNewConstMetric(c.lastSuccessDesc, prometheus.GaugeValue, unixSeconds(c.lastSuccess), c.labels...)
+	}
+}
+
+func unknownLabels(options Options) []string {
+	return []string{
+		firstNonEmpty(options.OrganizationID, unknownLabel),
+		firstNonEmpty(options.ProductPlanUniqueID, unknownLabel),
+		firstNonEmpty(options.InstanceID, unknownLabel),
+	}
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+package promcollector
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/internal/testcert"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/validator"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var keyPEM, certPEM = testcert.New()
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	collector := NewCollector(Options{
+		InstanceID: "instance-1",
+		Now:        func() time.Time { return now },
+	})
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	// Nothing but the counters is reported before the first check
+	count, err := testutil.GatherAndCount(registry)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	watcher := validator.NewWatcher(validator.WatcherOptions{
+		ValidationOptions: validator.ValidationOptions{
+			SkipCertificateValidation: true,
+			LicenseSource:             validator.NewBytesSource([]byte(envelope.String())),
+			CertSource:                validator.NewBytesSource(certPEM),
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+			CurrentTime:               now,
+		},
+		OnCheck: collector.Observe,
+	})
+	watcher.Check()
+	watcher.Check()
+
+	// The org and plan labels are taken from the license
+	expected := `
+# HELP license_certificate_expiry_timestamp_seconds Expiration time of the license signing certificate, in seconds since the epoch.
+# TYPE license_certificate_expiry_timestamp_seconds gauge
+license_certificate_expiry_timestamp_seconds{instance="instance-1",org="orgId",plan="SKU"} 2.2089888e+09
+# HELP license_expiry_timestamp_seconds Expiration time of the license, in seconds since the epoch.
+# TYPE license_expiry_timestamp_seconds gauge
+license_expiry_timestamp_seconds{instance="instance-1",org="orgId",plan="SKU"} 1.893456e+09
+# HELP license_last_successful_validation_timestamp_seconds Time of the last successful license validation, in seconds since the epoch.
+# TYPE license_last_successful_validation_timestamp_seconds gauge
+license_last_successful_validation_timestamp_seconds{instance="instance-1",org="orgId",plan="SKU"} 1.7987616e+09
+# HELP license_remaining_seconds Seconds until the license expires, negative once expired.
+# TYPE license_remaining_seconds gauge
+license_remaining_seconds{instance="instance-1",org="orgId",plan="SKU"} 9.46944e+07
+# HELP license_validations_total License validations by result and reason.
+# TYPE license_validations_total counter
+license_validations_total{instance="instance-1",org="orgId",plan="SKU",reason="valid",result="success"} 2
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected)))
+
+	// A failed check counts by reason and keeps the last successful validation time
+	collector.Observe(&validator.LicenseStatus{
+		State:     validator.LicenseStateExpired,
+		Reason:    validator.ReasonExpired,
+		Envelope:  envelope,
+		Verified:  true,
+		CheckedAt: now.Add(time.Hour),
+	})
+	expected = `
+# HELP license_last_successful_validation_timestamp_seconds Time of the last successful license validation, in seconds since the epoch.
+# TYPE license_last_successful_validation_timestamp_seconds gauge
+license_last_successful_validation_timestamp_seconds{instance="instance-1",org="orgId",plan="SKU"} 1.7987616e+09
+# HELP license_validations_total License validations by result and reason.
+# TYPE license_validations_total counter
+license_validations_total{instance="instance-1",org="orgId",plan="SKU",reason="expired",result="failure"} 1
+license_validations_total{instance="instance-1",org="orgId",plan="SKU",reason="valid",result="success"} 2
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"license_last_successful_validation_timestamp_seconds", "license_validations_total"))
+}
+
+func TestCollector_Namespace(t *testing.T) {
+	t.Parallel()
+
+	collector := NewCollector(Options{Namespace: "product", OrganizationID: "orgId", ProductPlanUniqueID: "SKU"})
+	collector.Observe(&validator.LicenseStatus{State: validator.LicenseStateInvalid, Reason: validator.ReasonUnavailable})
+
+	expected := `
+# HELP product_validations_total License validations by result and reason.
+# TYPE product_validations_total counter
+product_validations_total{instance="unknown",org="orgId",plan="SKU",reason="unavailable",result="failure"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector))
+}
+
+func TestCollector_UnverifiedLicense(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	envelope.License.OrganizationID = "other-org"
+
+	// Labels and expiry are never taken from a license whose signature was not verified
+	collector := NewCollector(Options{})
+	collector.Observe(&validator.LicenseStatus{
+		State:    validator.LicenseStateInvalid,
+		Reason:   validator.ReasonInvalidSignature,
+		Envelope: envelope,
+	})
+
+	expected := `
+# HELP license_validations_total License validations by result and reason.
+# TYPE license_validations_total counter
+license_validations_total{instance="unknown",org="unknown",plan="unknown",reason="invalid_signature",result="failure"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector))
+}
@@ -0,0 +1,351 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// maxSourceSize caps how much is read from a license or certificate source before parsing.
+const maxSourceSize = 1 << 20
+
+// LicenseSource loads the content of a license or certificate file.
+type LicenseSource interface {
+	Load(ctx context.Context) ([]byte, error)
+}
+
+// VersionedSource is implemented by sources that can cheaply tell whether their content changed,
+// which lets a Watcher re-validate as soon as the content is updated.
+type VersionedSource interface {
+	LicenseSource
+	Version(ctx context.Context) (string, error)
+}
+
+// WatchableSource is implemented by sources that are notified when their content changes, such as a Kubernetes
+// Secret. Watch calls changed for every update and blocks until the context is done.
+type WatchableSource interface {
+	LicenseSource
+	Watch(ctx context.Context, changed func()) error
+}
+
+type LicenseSourceFunc func(ctx context.Context) ([]byte, error)
+
+func (f LicenseSourceFunc) Load(ctx context.Context) ([]byte, error) {
+	return f(ctx)
+}
+
+type fileSource struct {
+	path string
+}
+
+func NewFileSource(path string) LicenseSource {
+	return &fileSource{path: path}
+}
+
+func (s *fileSource) Load(ctx context.Context) ([]byte, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readLimited(file)
+}
+
+func (s *fileSource) Version(ctx context.Context) (string, error) {
+	return fileVersion(s.path), nil
+}
+
+func (s *fileSource) String() string {
+	return "file " + s.path
+}
+
+type fsSource struct {
+	fsys fs.FS
+	name string
+}
+
+func NewFSSource(fsys fs.FS, name string) LicenseSource {
+	return &fsSource{fsys: fsys, name: name}
+}
+
+func (s *fsSource) Load(ctx context.Context) ([]byte, error) {
+	file, err := s.fsys.Open(s.name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readLimited(file)
+}
+
+func (s *fsSource) String() string {
+	return "fs " + s.name
+}
+
+type readerSource struct {
+	reader io.Reader
+	once   sync.Once
+	data   []byte
+	err    error
+}
+
+// NewReaderSource reads the reader once, on the first load, and returns the same content afterwards.
+func NewReaderSource(reader io.Reader) LicenseSource {
+	return &readerSource{reader: reader}
+}
+
+func (s *readerSource) Load(ctx context.Context) ([]byte, error) {
+	s.once.Do(func() {
+		s.data, s.err = readLimited(s.reader)
+	})
+	return s.data, s.err
+}
+
+func (s *readerSource) String() string {
+	return "reader"
+}
+
+type bytesSource struct {
+	data []byte
+}
+
+func NewBytesSource(data []byte) LicenseSource {
+	return &bytesSource{data: data}
+}
+
+func (s *bytesSource) Load(ctx context.Context) ([]byte, error) {
+	if len(s.data) > maxSourceSize {
+		return nil, errSourceTooLarge
+	}
+	return s.data, nil
+}
+
+func (s *bytesSource) String() string {
+	return "bytes"
+}
+
+type envSource struct {
+	name string
+}
+
+// NewEnvSource reads the content from an environment variable. JSON licenses and PEM certificates are used as is,
+// any other value is decoded from base64.
+func NewEnvSource(name string) LicenseSource {
+	return &envSource{name: name}
+}
+
+func (s *envSource) Load(ctx context.Context) ([]byte, error) {
+	value, ok := os.LookupEnv(s.name)
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", s.name)
+	}
+	if len(value) > maxSourceSize {
+		return nil, errSourceTooLarge
+	}
+
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "{") || strings.HasPrefix(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "environment variable %s is neither JSON, PEM nor base64", s.name)
+	}
+	return decoded, nil
+}
+
+func (s *envSource) Version(ctx context.Context) (string, error) {
+	// Hash the value so the content is never kept as a version
+	sum := sha256.Sum256([]byte(os.Getenv(s.name)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (s *envSource) String() string {
+	return "env " + s.name
+}
+
+type httpSource struct {
+	url           string
+	client        *http.Client
+	allowInsecure bool
+}
+
+type HTTPSourceOption func(*httpSource)
+
+// WithInsecureHTTP allows plain HTTP URLs, such as a sidecar serving the license on localhost. The content can
+// then be read and replaced by anyone on the network path, so it must never be used to load the signing
+// certificate without certificate validation.
+func WithInsecureHTTP() HTTPSourceOption {
+	return func(s *httpSource) {
+		s.allowInsecure = true
+	}
+}
+
+// NewHTTPSource downloads the content from an HTTPS URL with the given client, or http.DefaultClient when nil.
+// Plain HTTP URLs are rejected since the content is used to establish trust, unless WithInsecureHTTP is set.
+func NewHTTPSource(rawURL string, client *http.Client, opts ...HTTPSourceOption) LicenseSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	source := &httpSource{url: rawURL, client: client}
+	for _, opt := range opts {
+		opt(source)
+	}
+	return source
+}
+
+func (s *httpSource) Load(ctx context.Context) ([]byte, error) {
+	parsed, err := url.Parse(s.url)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "https" && (!s.allowInsecure || parsed.Scheme != "http") {
+		return nil, fmt.Errorf("license source url must use https, got %q", parsed.Scheme)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from license source %s", response.StatusCode, parsed.Redacted())
+	}
+	return readLimited(response.Body)
+}
+
+func (s *httpSource) String() string {
+	return "url " + s.url
+}
+
+type chainSource struct {
+	sources []LicenseSource
+}
+
+// NewChainSource loads from the first source that succeeds, in order.
+func NewChainSource(sources ...LicenseSource) LicenseSource {
+	return &chainSource{sources: sources}
+}
+
+func (s *chainSource) Load(ctx context.Context) ([]byte, error) {
+	var errs []error
+	for _, source := range s.sources {
+		data, err := source.Load(ctx)
+		if err == nil {
+			return data, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no license sources configured")
+	}
+	return nil, errors.Wrap(stderrors.Join(errs...), "all license sources failed")
+}
+
+func (s *chainSource) Version(ctx context.Context) (string, error) {
+	versions := make([]string, 0, len(s.sources))
+	for _, source := range s.sources {
+		version, err := sourceVersion(ctx, source)
+		if err != nil {
+			return "", err
+		}
+		versions = append(versions, version)
+	}
+	return strings.Join(versions, "|"), nil
+}
+
+// sourceVersion returns the version of a versioned source, or an empty string for other sources.
+func sourceVersion(ctx context.Context, source LicenseSource) (string, error) {
+	versioned, ok := source.(VersionedSource)
+	if !ok {
+		return "", nil
+	}
+	return versioned.Version(ctx)
+}
+
+var errSourceTooLarge = fmt.Errorf("license source exceeds %d bytes", maxSourceSize)
+
+func readLimited(reader io.Reader) ([]byte, error) {
+	var buffer bytes.Buffer
+	n, err := io.Copy(&buffer, io.LimitReader(reader, maxSourceSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > maxSourceSize {
+		return nil, errSourceTooLarge
+	}
+	return buffer.Bytes(), nil
+}
+
+// loadLicenseFiles loads the license and certificate from the configured sources, falling back to the
+// configured paths. When no source is configured, both files are read from the same version of their volume.
+func loadLicenseFiles(ctx context.Context, config *ValidatorConfig) (*licenseFiles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if config.LicenseSource == nil && config.CertSource == nil {
+		return readLicenseFiles(config.LicensePath, config.CertPath)
+	}
+
+	licenseSource, certSource := configSources(config)
+	license, err := licenseSource.Load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load license")
+	}
+	cert, err := certSource.Load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load certificate")
+	}
+
+	return &licenseFiles{
+		License: license,
+		Cert:    cert,
+		Version: licenseFilesVersionFromConfig(ctx, config),
+	}, nil
+}
+
+// licenseFilesVersionFromConfig identifies the current version of the configured license and certificate.
+func licenseFilesVersionFromConfig(ctx context.Context, config *ValidatorConfig) string {
+	if config.LicenseSource == nil && config.CertSource == nil {
+		return licenseFilesVersion(config.LicensePath, config.CertPath)
+	}
+
+	licenseSource, certSource := configSources(config)
+	licenseVersion, licenseErr := sourceVersion(ctx, licenseSource)
+	certVersion, certErr := sourceVersion(ctx, certSource)
+	if licenseErr != nil || certErr != nil {
+		return "unavailable"
+	}
+	return licenseVersion + "|" + certVersion
+}
+
+func configSources(config *ValidatorConfig) (licenseSource, certSource LicenseSource) {
+	licenseSource, certSource = config.LicenseSource, config.CertSource
+	if licenseSource == nil {
+		licenseSource = NewFileSource(config.LicensePath)
+	}
+	if certSource == nil {
+		certSource = NewFileSource(config.CertPath)
+	}
+	return licenseSource, certSource
+}
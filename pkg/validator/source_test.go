@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseSources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	content := []byte(`{"license":{}}`)
+
+	path := filepath.Join(t.TempDir(), "license.lic")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	sources := map[string]LicenseSource{
+		"file":   NewFileSource(path),
+		"fs":     NewFSSource(fstest.MapFS{"license.lic": {Data: content}}, "license.lic"),
+		"reader": NewReaderSource(bytes.NewReader(content)),
+		"bytes":  NewBytesSource(content),
+	}
+	for name, source := range sources {
+		data, err := source.Load(ctx)
+		require.NoError(t, err, name)
+		assert.Equal(t, content, data, name)
+
+		// Loading again returns the same content
+		data, err = source.Load(ctx)
+		require.NoError(t, err, name)
+		assert.Equal(t, content, data, name)
+	}
+
+	_, err := NewFileSource(filepath.Join(t.TempDir(), "missing.lic")).Load(ctx)
+	require.Error(t, err)
+}
+
+func TestLicenseSources_SizeLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	content := bytes.Repeat([]byte("a"), maxSourceSize+1)
+
+	_, err := NewReaderSource(bytes.NewReader(content)).Load(ctx)
+	require.ErrorIs(t, err, errSourceTooLarge)
+
+	_, err = NewBytesSource(content).Load(ctx)
+	require.ErrorIs(t, err, errSourceTooLarge)
+}
+
+func TestEnvSource(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("TEST_LICENSE_SOURCE", `{"license":{}}`)
+	data, err := NewEnvSource("TEST_LICENSE_SOURCE").Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, `{"license":{}}`, string(data))
+
+	t.Setenv("TEST_LICENSE_SOURCE", string(certPEM))
+	data, err = NewEnvSource("TEST_LICENSE_SOURCE").Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(certPEM)), string(data))
+
+	t.Setenv("TEST_LICENSE_SOURCE", base64.StdEncoding.EncodeToString([]byte(`{"license":{}}`)))
+	data, err = NewEnvSource("TEST_LICENSE_SOURCE").Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, `{"license":{}}`, string(data))
+
+	t.Setenv("TEST_LICENSE_SOURCE", "not base64!")
+	_, err = NewEnvSource("TEST_LICENSE_SOURCE").Load(ctx)
+	require.Error(t, err)
+
+	_, err = NewEnvSource("TEST_LICENSE_SOURCE_UNSET").Load(ctx)
+	require.Error(t, err)
+}
+
+func TestHTTPSource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/license.lic" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"license":{}}`))
+	}))
+	// The untrusted client below fails the handshake, which the server would log
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	data, err := NewHTTPSource(server.URL+"/license.lic", server.Client()).Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, `{"license":{}}`, string(data))
+
+	_, err = NewHTTPSource(server.URL+"/missing.lic", server.Client()).Load(ctx)
+	require.ErrorContains(t, err, "unexpected status 404")
+
+	// The default client does not trust the test server
+	_, err = NewHTTPSource(server.URL+"/license.lic", nil).Load(ctx)
+	require.Error(t, err)
+
+	_, err = NewHTTPSource("http://example.com/license.lic", nil).Load(ctx)
+	require.ErrorContains(t, err, "must use https")
+
+	// Plain HTTP is an explicit opt-in
+	plain := httptest.NewServer(server.Config.Handler)
+	defer plain.Close()
+	_, err = NewHTTPSource(plain.URL+"/license.lic", nil).Load(ctx)
+	require.ErrorContains(t, err, "must use https")
+	data, err = NewHTTPSource(plain.URL+"/license.lic", nil, WithInsecureHTTP()).Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, `{"license":{}}`, string(data))
+	_, err = NewHTTPSource("ftp://example.com/license.lic", nil, WithInsecureHTTP()).Load(ctx)
+	require.ErrorContains(t, err, "must use https")
+}
+
+func TestChainSource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	content := []byte(`{"license":{}}`)
+
+	data, err := NewChainSource(
+		NewFileSource(filepath.Join(t.TempDir(), "missing.lic")),
+		NewBytesSource(content),
+		LicenseSourceFunc(func(ctx context.Context) ([]byte, error) {
+			t.Fatal("sources after the first success must not be loaded")
+			return nil, nil
+		}),
+	).Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	_, err = NewChainSource(
+		NewFileSource(filepath.Join(t.TempDir(), "missing.lic")),
+		NewEnvSource("TEST_LICENSE_SOURCE_UNSET"),
+	).Load(ctx)
+	require.ErrorContains(t, err, "all license sources failed")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = NewChainSource().Load(ctx)
+	require.Error(t, err)
+}
+
+func TestValidateLicenseWithOptions_Sources(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	options := ValidationOptions{
+		SkipCertificateValidation: true,
+		LicenseSource:             NewBytesSource([]byte(envelope.String())),
+		CertSource:                NewBytesSource(certPEM),
+		OrganizationID:            "orgId",
+		ProductPlanUniqueID:       "SKU",
+		InstanceID:                "instance-1",
+	}
+	require.NoError(t, ValidateLicenseWithOptions(options))
+
+	// An unusable certificate is rejected
+	options.CertSource = NewBytesSource([]byte("not a certificate"))
+	require.Error(t, ValidateLicenseWithOptions(options))
+
+	// Only the license comes from a source, the certificate from its path
+	certPath := filepath.Join(t.TempDir(), "license.crt")
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0o600))
+	options.CertSource = nil
+	options.CertPath = certPath
+	require.NoError(t, ValidateLicenseWithOptions(options))
+
+	validator, err := NewValidatorFromConfig(&ValidatorConfig{CertSource: NewBytesSource(certPEM)})
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateLicense(envelope, "orgId", "SKU", "instance-1", time.Now()))
+}
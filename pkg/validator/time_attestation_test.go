@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/certificate"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTimeAttestation signs an attestation for an arbitrary time, which the generator does not allow.
+func newTimeAttestation(t *testing.T, issueTime time.Time) *common.TimeAttestationEnvelope {
+	t.Helper()
+
+	key, err := certificate.LoadPrivateKeyFromBytes(keyPEM)
+	require.NoError(t, err)
+	attestation := common.NewTimeAttestation(issueTime)
+	attestationBytes, err := attestation.Bytes()
+	require.NoError(t, err)
+	signature, err := certificate.Sign(key, attestationBytes)
+	require.NoError(t, err)
+	return common.NewTimeAttestationEnvelope(attestation, signature)
+}
+
+func TestValidator_VerifyTimeAttestation(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+	licenseValidator, err := NewValidatorFromBytes(certPEM)
+	require.NoError(t, err)
+	validator, ok := licenseValidator.(ContextValidator)
+	require.True(t, ok)
+
+	attestation, err := manager.IssueTimeAttestation()
+	require.NoError(t, err)
+	issueTime, err := validator.VerifyTimeAttestation(attestation)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), issueTime, time.Minute)
+
+	attestation.Attestation.IssueTime = time.Now().Add(time.Hour).UTC().Format(time.RFC3339Nano)
+	_, err = validator.VerifyTimeAttestation(attestation)
+	require.ErrorIs(t, err, ErrInvalidTimeAttestation)
+
+	_, err = validator.VerifyTimeAttestation(nil)
+	require.ErrorIs(t, err, ErrInvalidTimeAttestation)
+
+	// A license signature does not make a valid time attestation
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	_, err = validator.VerifyTimeAttestation(common.NewTimeAttestationEnvelope(
+		&common.TimeAttestation{Type: common.TimeAttestationType, ID: envelope.License.ID, IssueTime: envelope.License.CreationTime},
+		envelope.Signature,
+	))
+	require.ErrorIs(t, err, ErrInvalidTimeAttestation)
+}
+
+func TestValidateLicenseWithResult_TimeAttestation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(24*time.Hour))
+	require.NoError(t, err)
+
+	attestation, err := manager.IssueTimeAttestationBase64()
+	require.NoError(t, err)
+
+	options := ValidationOptions{
+		SkipCertificateValidation: true,
+		LicenseSource:             NewBytesSource([]byte(envelope.String())),
+		CertSource:                NewBytesSource(certPEM),
+		OrganizationID:            "orgId",
+		ProductPlanUniqueID:       "SKU",
+		InstanceID:                "instance-1",
+		// The system clock is not trustworthy
+		CurrentTime:           now.Add(-30 * 24 * time.Hour),
+		TimeAttestationSource: NewBytesSource([]byte(attestation)),
+	}
+	require.NoError(t, ValidateLicenseWithOptions(options))
+
+	// The attested time expires the license, whatever the system clock says
+	options.TimeAttestationSource = NewBytesSource([]byte(newTimeAttestation(t, now.Add(48*time.Hour)).String()))
+	require.ErrorIs(t, ValidateLicenseWithOptions(options), ErrLicenseExpired)
+
+	// The clock still moves the current time forward, so stale attestations are rejected
+	options.TimeAttestationSource = NewBytesSource([]byte(attestation))
+	options.CurrentTime = now.Add(25 * time.Hour)
+	result := ValidateLicenseWithResult(options)
+	require.ErrorIs(t, result.Err, ErrTimeAttestationStale)
+	assert.Equal(t, ReasonUntrustedTime, result.Reason)
+
+	options.TimeAttestationMaxAge = 48 * time.Hour
+	require.ErrorIs(t, ValidateLicenseWithOptions(options), ErrLicenseExpired)
+
+	options.TimeAttestationSource = NewBytesSource([]byte("not an attestation"))
+	require.ErrorIs(t, ValidateLicenseWithOptions(options), ErrInvalidTimeAttestation)
+}
@@ -1,8 +1,14 @@
 package validator
 
 import (
-	"os"
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
 	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/pkg/errors"
 )
 
 type ValidationOptions struct {
@@ -14,6 +20,65 @@ type ValidationOptions struct {
 	OrganizationID            string
 	ProductPlanUniqueID       string
 	InstanceID                string
+	// InstanceIDSources discover the instance ID when InstanceID is not set, instead of reading the INSTANCE_ID
+	// environment variable. The validation fails with ErrInstanceIDConflict when they disagree.
+	InstanceIDSources []InstanceIDSource
+	// MatchPolicy fails the validation when an expected value it requires is empty, and accepts the allowed
+	// organizations and plans. Without it, the check of a field whose expected value is empty is skipped.
+	MatchPolicy *common.MatchPolicy
+	// HostFingerprinter checks node-locked licenses against the current host. Licenses bound to a host fail
+	// with ErrHostFingerprintMismatch without one.
+	HostFingerprinter HostFingerprinter
+	// LicenseSource and CertSource override LicensePath and CertPath. When neither is set, the license and
+	// certificate files are read together from the same version of their volume.
+	LicenseSource LicenseSource
+	CertSource    LicenseSource
+	// EnforcementMode defaults to enforcing the license.
+	EnforcementMode EnforcementMode
+	// EnforcementModeFromEnv reads the mode from the SERVICE_PLAN_SUBSCRIPTION_LICENSE_ENFORCEMENT_MODE
+	// environment variable when EnforcementMode is not set. The environment is controlled by whoever runs the
+	// product, so only enable it when they are trusted to relax enforcement.
+	EnforcementModeFromEnv bool
+	// HighWaterMark detects system clock rollbacks when set. It is ratcheted forward with the current time,
+	// the license issue time and the license file modification times.
+	HighWaterMark *HighWaterMark
+	// TimeAttestationSource provides a time attestation signed by the license issuer. When set, the attested time
+	// replaces the system clock, which is only trusted to move the current time forward within TimeAttestationMaxAge.
+	TimeAttestationSource LicenseSource
+	// TimeAttestationMaxAge is how long a time attestation stays fresh. Defaults to 24 hours.
+	TimeAttestationMaxAge time.Duration
+	// TimestampVerifier verifies the RFC 3161 timestamp token of licenses. When a license carries a valid token,
+	// the signing certificate is validated at the time the license was signed instead of the current time.
+	TimestampVerifier TimestampVerifier
+	// RequireTimestamp rejects licenses without a timestamp token. It requires a TimestampVerifier.
+	RequireTimestamp bool
+	// Logger receives the outcomes logged by the warn and audit modes. Defaults to slog.Default().
+	Logger *slog.Logger
+	// Instrumentation is notified of the certificate and license validations.
+	Instrumentation common.Instrumentation
+}
+
+// config returns the validator configuration from the environment, overridden by the options.
+func (options ValidationOptions) config() *ValidatorConfig {
+	config := NewValidatorConfigFromEnv()
+
+	if options.CertPath != "" {
+		config.CertPath = options.CertPath
+	}
+
+	if options.LicensePath != "" {
+		config.LicensePath = options.LicensePath
+	}
+
+	if options.LicenseSource != nil {
+		config.LicenseSource = options.LicenseSource
+	}
+
+	if options.CertSource != nil {
+		config.CertSource = options.CertSource
+	}
+
+	return config
 }
 
 func ValidateLicense(orgId, sku string) (err error) {
@@ -23,55 +88,216 @@ func ValidateLicense(orgId, sku string) (err error) {
 	})
 }
 
+// ValidateLicenseWithOptions validates the license and returns the failure only when the enforcement mode enforces it.
 func ValidateLicenseWithOptions(
 	options ValidationOptions,
 ) (err error) {
-	config := NewValidatorConfigFromEnv()
+	return ValidateLicenseWithOptionsContext(context.Background(), options)
+}
 
-	if options.CertPath != "" {
-		config.CertPath = options.CertPath
+// ValidateLicenseWithOptionsContext is ValidateLicenseWithOptions, loading the license and certificate with the context.
+// A done context fails the validation whatever the enforcement mode.
+func ValidateLicenseWithOptionsContext(ctx context.Context, options ValidationOptions) error {
+	result := ValidateLicenseWithResultContext(ctx, options)
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	return result.Error()
+}
 
-	if options.LicensePath != "" {
-		config.LicensePath = options.LicensePath
+// ValidateLicenseWithResult validates the license and reports the outcome along with the enforcement mode applied.
+func ValidateLicenseWithResult(options ValidationOptions) *ValidationResult {
+	return ValidateLicenseWithResultContext(context.Background(), options)
+}
+
+func ValidateLicenseWithResultContext(ctx context.Context, options ValidationOptions) *ValidationResult {
+	validated, err := validateLicenseWithOptions(ctx, options)
+	result := newValidationResult(options, options.enforcementMode(options.config()), validated.envelope, err)
+	result.CertificateExpiresAt = validated.certExpiresAt
+	result.InstanceID = validated.instanceID
+	result.Verified = validated.verified
+	return result
+}
+
+// validatedLicense is what was learned about the license during a validation, even a failed one.
+type validatedLicense struct {
+	// envelope is set whenever the license could be decoded.
+	envelope *common.LicenseEnvelope
+	// certExpiresAt is set once the signing certificate could be parsed.
+	certExpiresAt time.Time
+	instanceID    DiscoveredInstanceID
+	// verified is set once the signature of the envelope was verified against a trusted certificate, even
+	// when the license is not valid for other reasons.
+	verified bool
+}
+
+// validateLicenseWithOptions validates the license and returns what it learned about it.
+func validateLicenseWithOptions(
+	ctx context.Context,
+	options ValidationOptions,
+) (validated validatedLicense, err error) {
+	config := options.config()
+
+	validated.instanceID, err = options.instanceID(ctx, config)
+	if err != nil {
+		return validated, err
 	}
 
-	licenseBytes, err := os.ReadFile(config.LicensePath)
+	files, err := loadLicenseFiles(ctx, config)
 	if err != nil {
-		return err
+		return validated, err
 	}
 
-	validator, err := NewValidatorFromConfig(config)
+	validated.envelope, err = common.DecodeLicenseEnvelopeFromBytes(files.License)
 	if err != nil {
-		return err
+		return validated, err
+	}
+	envelope := validated.envelope
+
+	validator, err := newValidatorFromBytes(files.Cert,
+		WithInstrumentation(options.Instrumentation),
+		WithMatchPolicy(options.MatchPolicy),
+		WithHostFingerprinter(options.HostFingerprinter))
+	if err != nil {
+		return validated, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
 	}
+	validated.certExpiresAt = validator.cert.NotAfter
 
 	currentTime := time.Now().UTC()
 	if !options.CurrentTime.IsZero() {
 		currentTime = options.CurrentTime
 	}
 
+	if options.TimeAttestationSource != nil {
+		currentTime, err = attestedTime(ctx, validator, options, currentTime)
+		if err != nil {
+			return validated, err
+		}
+	}
+
+	certificateTime, err := signingTime(options, envelope, currentTime)
+	if err != nil {
+		return validated, err
+	}
+
 	if !options.SkipCertificateValidation {
 		certificateDomain := options.CertificateDomain
 		if certificateDomain == "" {
 			certificateDomain = signingCertificateValidDnsName
 		}
 
-		err = validator.ValidateCertificate(certificateDomain, currentTime)
+		err = validator.ValidateCertificateContext(ctx, certificateDomain, certificateTime)
 		if err != nil {
-			return
+			return validated, err
 		}
 	}
 
-	instanceID := options.InstanceID
-	if options.InstanceID == "" {
-		instanceID = config.InstanceID
+	if envelope.IsValid() {
+		validated.verified = validator.verifySignature(envelope) == nil
 	}
 
-	err = validator.ValidateLicenseBytes(licenseBytes, options.OrganizationID, options.ProductPlanUniqueID, instanceID, currentTime)
+	err = validator.ValidateLicenseContext(ctx, envelope, options.OrganizationID, options.ProductPlanUniqueID, validated.instanceID.InstanceID, currentTime)
 	if err != nil {
-		return err
+		return validated, err
+	}
+
+	// The license issue time is only trusted once its signature was verified
+	if options.HighWaterMark != nil {
+		issueTime, _ := envelope.License.GetCreationTime()
+		err = options.HighWaterMark.Observe(currentTime, issueTime, files.ModTime)
+		if err != nil {
+			return validated, err
+		}
+	}
+
+	return validated, nil
+}
+
+// instanceID returns the instance ID set in code, then discovered by the sources or read from the environment.
+func (options ValidationOptions) instanceID(ctx context.Context, config *ValidatorConfig) (DiscoveredInstanceID, error) {
+	switch {
+	case options.InstanceID != "":
+		return DiscoveredInstanceID{InstanceID: options.InstanceID, Source: "options"}, nil
+	case len(options.InstanceIDSources) > 0:
+		return DiscoverInstanceID(ctx, options.InstanceIDSources...)
+	case config.InstanceID != "":
+		return DiscoveredInstanceID{InstanceID: config.InstanceID, Source: "env " + licenseInstanceIDEnv}, nil
+	default:
+		return DiscoveredInstanceID{}, nil
+	}
+}
+
+// attestedTime returns the later of the attested time and the clock, provided the attestation is still fresh
+// according to the clock.
+func attestedTime(ctx context.Context, validator *Validator, options ValidationOptions, clock time.Time) (time.Time, error) {
+	data, err := options.TimeAttestationSource.Load(ctx)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to load time attestation")
+	}
+
+	attestation, err := decodeTimeAttestation(data)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidTimeAttestation, err)
+	}
+
+	issueTime, err := validator.VerifyTimeAttestationContext(ctx, attestation)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	maxAge := options.TimeAttestationMaxAge
+	if maxAge <= 0 {
+		maxAge = defaultTimeAttestationMaxAge
+	}
+	if clock.After(issueTime.Add(maxAge)) {
+		return time.Time{}, ErrTimeAttestationStale
 	}
 
-	return nil
+	if clock.After(issueTime) {
+		return clock, nil
+	}
+	return issueTime, nil
+}
+
+// decodeTimeAttestation accepts time attestations encoded as JSON or base64.
+func decodeTimeAttestation(data []byte) (*common.TimeAttestationEnvelope, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		return common.DecodeTimeAttestationEnvelopeFromBytes(data)
+	}
+	return common.DecodeTimeAttestationEnvelopeFromBase64(string(data))
+}
+
+// TimestampVerifier verifies an RFC 3161 timestamp token over data and returns the time it attests.
+// The timestamp package provides an implementation.
+type TimestampVerifier interface {
+	Verify(token, data []byte) (time.Time, error)
+}
+
+// signingTime returns the time the license was signed according to its timestamp token, or the current time when
+// timestamps are not verified or the license has none.
+func signingTime(options ValidationOptions, envelope *common.LicenseEnvelope, currentTime time.Time) (time.Time, error) {
+	if options.TimestampVerifier == nil {
+		if options.RequireTimestamp {
+			return time.Time{}, fmt.Errorf("%w: no timestamp verifier configured", ErrTimestampRequired)
+		}
+		return currentTime, nil
+	}
+
+	if len(envelope.Timestamp) == 0 {
+		if options.RequireTimestamp {
+			return time.Time{}, ErrTimestampRequired
+		}
+		return currentTime, nil
+	}
+
+	// The token covers the signature, which is verified along with the license
+	signedAt, err := options.TimestampVerifier.Verify(envelope.Timestamp, envelope.Signature)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+	if signedAt.After(currentTime.Add(maxTimestampClockSkew)) {
+		return time.Time{}, fmt.Errorf("%w: signed in the future", ErrInvalidTimestamp)
+	}
+	return signedAt, nil
 }
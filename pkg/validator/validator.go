@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"context"
 	"crypto/x509"
 	"fmt"
 	"time"
@@ -18,19 +19,85 @@ type ValidatorInterface interface {
 	ValidateCertificate(certificateDomain string, currentTime time.Time) error
 }
 
+// ContextValidator extends ValidatorInterface with the context variants of the validations and the newer
+// methods. The validators returned by the constructors implement it.
+type ContextValidator interface {
+	ValidatorInterface
+	SelectLatestLicense(envelopes []*common.LicenseEnvelope, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) (*common.LicenseEnvelope, error)
+	VerifyTimeAttestation(attestation *common.TimeAttestationEnvelope) (time.Time, error)
+
+	// Context variants return the context error once it is done, without validating further.
+	ValidateLicenseContext(ctx context.Context, envelope *common.LicenseEnvelope, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error
+	ValidateLicenseStringContext(ctx context.Context, envelopeJson string, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error
+	ValidateLicenseBytesContext(ctx context.Context, envelopeBytes []byte, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error
+	ValidateLicenseBase64Context(ctx context.Context, envelopeBase64 string, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error
+	ValidateCertificateContext(ctx context.Context, certificateDomain string, currentTime time.Time) error
+	SelectLatestLicenseContext(ctx context.Context, envelopes []*common.LicenseEnvelope, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) (*common.LicenseEnvelope, error)
+	VerifyTimeAttestationContext(ctx context.Context, attestation *common.TimeAttestationEnvelope) (time.Time, error)
+}
+
 type Validator struct {
 	cert              *x509.Certificate
 	intermediateCerts []*x509.Certificate
+	instrumentation   common.Instrumentation
+	matchPolicy       *common.MatchPolicy
+	hostFingerprinter HostFingerprinter
+}
+
+// HostFingerprinter computes the hashes of host identifiers, keyed by factor name, to check node-locked licenses.
+// The fingerprint package provides an implementation.
+type HostFingerprinter interface {
+	Fingerprint(factors []string) (map[string]string, error)
+}
+
+type ValidatorOption func(*Validator)
+
+// WithInstrumentation reports license and certificate validations to the instrumentation.
+func WithInstrumentation(instrumentation common.Instrumentation) ValidatorOption {
+	return func(m *Validator) {
+		m.instrumentation = instrumentation
+	}
+}
+
+// WithMatchPolicy matches the organization, plan and instance of licenses according to the policy.
+func WithMatchPolicy(policy *common.MatchPolicy) ValidatorOption {
+	return func(m *Validator) {
+		m.matchPolicy = policy
+	}
+}
+
+// WithHostFingerprinter checks licenses bound to a host against the fingerprint of the current host. Licenses
+// bound to a host fail to validate without one.
+func WithHostFingerprinter(fingerprinter HostFingerprinter) ValidatorOption {
+	return func(m *Validator) {
+		m.hostFingerprinter = fingerprinter
+	}
 }
 
-func NewValidator(cert *x509.Certificate, intermediateCerts []*x509.Certificate) ValidatorInterface {
-	return &Validator{
+func NewValidator(cert *x509.Certificate, intermediateCerts []*x509.Certificate, opts ...ValidatorOption) ValidatorInterface {
+	return newValidator(cert, intermediateCerts, opts...)
+}
+
+func newValidator(cert *x509.Certificate, intermediateCerts []*x509.Certificate, opts ...ValidatorOption) *Validator {
+	m := &Validator{
 		cert:              cert,
 		intermediateCerts: intermediateCerts,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+func NewValidatorFromBytes(certPEM []byte, opts ...ValidatorOption) (ValidatorInterface, error) {
+	m, err := newValidatorFromBytes(certPEM, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func NewValidatorFromBytes(certPEM []byte) (ValidatorInterface, error) {
+func newValidatorFromBytes(certPEM []byte, opts ...ValidatorOption) (*Validator, error) {
 	certs, err := certificate.LoadCertificateChainFromBytes(certPEM)
 	if err != nil {
 		return nil, err
@@ -39,12 +106,12 @@ func NewValidatorFromBytes(certPEM []byte) (ValidatorInterface, error) {
 		return nil, fmt.Errorf("no certificates found in PEM data")
 	}
 	if len(certs) == 1 {
-		return NewValidator(certs[0], nil), nil
+		return newValidator(certs[0], nil, opts...), nil
 	}
-	return NewValidator(certs[0], certs[1:]), nil
+	return newValidator(certs[0], certs[1:], opts...), nil
 }
 
-func NewValidatorFromFiles(certPath string) (ValidatorInterface, error) {
+func NewValidatorFromFiles(certPath string, opts ...ValidatorOption) (ValidatorInterface, error) {
 	certs, err := certificate.LoadCertificateChain(certPath)
 	if err != nil {
 		return nil, err
@@ -53,16 +120,70 @@ func NewValidatorFromFiles(certPath string) (ValidatorInterface, error) {
 		return nil, fmt.Errorf("no certificates found in PEM data")
 	}
 	if len(certs) == 1 {
-		return NewValidator(certs[0], nil), nil
+		return NewValidator(certs[0], nil, opts...), nil
 	}
-	return NewValidator(certs[0], certs[1:]), nil
+	return NewValidator(certs[0], certs[1:], opts...), nil
 }
 
-func NewValidatorFromConfig(config *ValidatorConfig) (ValidatorInterface, error) {
-	return NewValidatorFromFiles(config.CertPath)
+func NewValidatorFromSource(ctx context.Context, source LicenseSource, opts ...ValidatorOption) (ValidatorInterface, error) {
+	certPEM, err := source.Load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load certificate")
+	}
+	return NewValidatorFromBytes(certPEM, opts...)
+}
+
+func NewValidatorFromConfig(config *ValidatorConfig, opts ...ValidatorOption) (ValidatorInterface, error) {
+	return NewValidatorFromConfigContext(context.Background(), config, opts...)
+}
+
+func NewValidatorFromConfigContext(ctx context.Context, config *ValidatorConfig, opts ...ValidatorOption) (ValidatorInterface, error) {
+	if config.CertSource != nil {
+		return NewValidatorFromSource(ctx, config.CertSource, opts...)
+	}
+	return NewValidatorFromFiles(config.CertPath, opts...)
 }
 
 func (m *Validator) ValidateLicense(envelope *common.LicenseEnvelope, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
+	return m.ValidateLicenseContext(context.Background(), envelope, orgId, productPlanUniqueID, instanceID, currentTime)
+}
+
+func (m *Validator) ValidateLicenseContext(ctx context.Context, envelope *common.LicenseEnvelope, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
+	return m.observeLicenseValidation(ctx, func() (*common.LicenseEnvelope, error) {
+		return envelope, nil
+	}, orgId, productPlanUniqueID, instanceID, currentTime)
+}
+
+// observeLicenseValidation decodes and validates the license, reporting the outcome to the instrumentation.
+func (m *Validator) observeLicenseValidation(
+	ctx context.Context,
+	decode func() (*common.LicenseEnvelope, error),
+	orgId, productPlanUniqueID, instanceID string,
+	currentTime time.Time,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, end := common.StartOperation(ctx, m.instrumentation, common.OperationValidateLicense)
+
+	envelope, err := decode()
+	if err == nil {
+		err = m.validateLicense(envelope, orgId, productPlanUniqueID, instanceID, currentTime)
+	}
+
+	// Claims are only reported once the signature verified, so forged licenses cannot choose the attributes
+	info := common.OperationInfo{}
+	if m.instrumentation != nil && envelope.IsValid() && (err == nil || m.verifySignature(envelope) == nil) {
+		info = common.NewOperationInfo(envelope.License)
+	}
+	if err != nil {
+		info.Reason = string(ReasonFor(err))
+	}
+	end(info, err)
+	return err
+}
+
+func (m *Validator) validateLicense(envelope *common.LicenseEnvelope, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
 	if m.cert == nil {
 		return fmt.Errorf("signingCertificate is required to validate a license")
 	}
@@ -75,75 +196,213 @@ func (m *Validator) ValidateLicense(envelope *common.LicenseEnvelope, orgId, pro
 		return fmt.Errorf("envelope is invalid")
 	}
 
-	// Extract the signature
-	signature := envelope.Signature
-
 	// Extract the license
 	license := envelope.License
-	licenseBytes, err := license.Bytes()
+
+	// Check if the license is valid
+	err := license.IsValidWithPolicy(orgId, productPlanUniqueID, instanceID, m.matchPolicy)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %w", ErrLicenseMismatch, err)
 	}
 
-	// Check if the license is valid
-	err = license.IsValid(orgId, productPlanUniqueID, instanceID)
+	// Check if the license is bound to this host
+	err = m.matchHost(license.HostFingerprint)
 	if err != nil {
-		return errors.Wrap(err, "license is invalid")
+		return fmt.Errorf("%w: %w: %w", ErrLicenseMismatch, ErrHostFingerprintMismatch, err)
 	}
 
 	// Check if the license is expired
 	if license.IsExpiredAt(currentTime) {
-		return fmt.Errorf("license is expired")
+		return ErrLicenseExpired
+	}
+
+	// Check if the license is already usable
+	if license.IsNotYetValidAt(currentTime) {
+		return ErrLicenseNotYetValid
 	}
 
 	// Verify the signature
-	err = certificate.VerifySignature(m.cert, signature, licenseBytes)
+	err = m.verifySignature(envelope)
 	if err != nil {
-		return errors.Wrap(err, "failed to verify signature")
+		return err
 	}
 
 	// License is valid
 	return nil
 }
 
-func (m *Validator) ValidateLicenseBase64(envelopeBase64 string, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
-	// Decode the license envelope
-	envelope, err := common.DecodeLicenseEnvelopeFromBase64(envelopeBase64)
+// verifySignature checks the signature of the license alone, whatever its claims.
+func (m *Validator) verifySignature(envelope *common.LicenseEnvelope) error {
+	if m.cert == nil {
+		return fmt.Errorf("signingCertificate is required to verify a license")
+	}
+	licenseBytes, err := envelope.License.Bytes()
 	if err != nil {
 		return err
 	}
+	err = certificate.VerifySignature(m.cert, envelope.Signature, licenseBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	return nil
+}
 
-	// Validate the license
-	return m.ValidateLicense(envelope, orgId, productPlanUniqueID, instanceID, currentTime)
+func (m *Validator) ValidateLicenseBase64(envelopeBase64 string, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
+	return m.ValidateLicenseBase64Context(context.Background(), envelopeBase64, orgId, productPlanUniqueID, instanceID, currentTime)
+}
+
+func (m *Validator) ValidateLicenseBase64Context(ctx context.Context, envelopeBase64 string, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
+	return m.observeLicenseValidation(ctx, func() (*common.LicenseEnvelope, error) {
+		return common.DecodeLicenseEnvelopeFromBase64(envelopeBase64)
+	}, orgId, productPlanUniqueID, instanceID, currentTime)
 }
 
 func (m *Validator) ValidateLicenseString(envelopeJson string, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
-	// Decode the license envelope
-	envelope, err := common.DecodeLicenseEnvelopeFromString(envelopeJson)
-	if err != nil {
-		return err
-	}
+	return m.ValidateLicenseStringContext(context.Background(), envelopeJson, orgId, productPlanUniqueID, instanceID, currentTime)
+}
 
-	// Validate the license
-	return m.ValidateLicense(envelope, orgId, productPlanUniqueID, instanceID, currentTime)
+func (m *Validator) ValidateLicenseStringContext(ctx context.Context, envelopeJson string, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
+	return m.observeLicenseValidation(ctx, func() (*common.LicenseEnvelope, error) {
+		return common.DecodeLicenseEnvelopeFromString(envelopeJson)
+	}, orgId, productPlanUniqueID, instanceID, currentTime)
 }
 
 func (m *Validator) ValidateLicenseBytes(envelopeBytes []byte, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
-	// Decode the license envelope
-	envelope, err := common.DecodeLicenseEnvelopeFromBytes(envelopeBytes)
-	if err != nil {
-		return err
+	return m.ValidateLicenseBytesContext(context.Background(), envelopeBytes, orgId, productPlanUniqueID, instanceID, currentTime)
+}
+
+func (m *Validator) ValidateLicenseBytesContext(ctx context.Context, envelopeBytes []byte, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) error {
+	return m.observeLicenseValidation(ctx, func() (*common.LicenseEnvelope, error) {
+		return common.DecodeLicenseEnvelopeFromBytes(envelopeBytes)
+	}, orgId, productPlanUniqueID, instanceID, currentTime)
+}
+
+// SelectLatestLicense returns the newest valid license from a set of licenses sharing one lineage.
+// Licenses are ordered by version, then by creation time.
+func (m *Validator) SelectLatestLicense(envelopes []*common.LicenseEnvelope, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) (*common.LicenseEnvelope, error) {
+	return m.SelectLatestLicenseContext(context.Background(), envelopes, orgId, productPlanUniqueID, instanceID, currentTime)
+}
+
+func (m *Validator) SelectLatestLicenseContext(ctx context.Context, envelopes []*common.LicenseEnvelope, orgId, productPlanUniqueID, instanceID string, currentTime time.Time) (*common.LicenseEnvelope, error) {
+	var lineageID string
+	for _, envelope := range envelopes {
+		if !envelope.IsValid() {
+			continue
+		}
+		if lineageID == "" {
+			lineageID = envelope.License.GetLineageID()
+		} else if envelope.License.GetLineageID() != lineageID {
+			return nil, fmt.Errorf("licenses belong to different lineages %s and %s", lineageID, envelope.License.GetLineageID())
+		}
+	}
+
+	var latest *common.LicenseEnvelope
+	var lastErr error
+	for _, envelope := range envelopes {
+		if err := m.ValidateLicenseContext(ctx, envelope, orgId, productPlanUniqueID, instanceID, currentTime); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			lastErr = err
+			continue
+		}
+		if latest == nil || isNewerLicense(envelope.License, latest.License) {
+			latest = envelope
+		}
+	}
+
+	if latest == nil {
+		if lastErr == nil {
+			return nil, fmt.Errorf("no licenses provided")
+		}
+		return nil, errors.Wrap(lastErr, "no valid license found")
 	}
+	return latest, nil
+}
 
-	// Validate the license
-	return m.ValidateLicense(envelope, orgId, productPlanUniqueID, instanceID, currentTime)
+func isNewerLicense(license, other *common.License) bool {
+	if license.Version != other.Version {
+		return license.Version > other.Version
+	}
+	creationTime, _ := license.GetCreationTime()
+	otherCreationTime, _ := other.GetCreationTime()
+	return creationTime.After(otherCreationTime)
 }
 
 func (m *Validator) ValidateCertificate(certificateDomain string, currentTime time.Time) error {
+	return m.ValidateCertificateContext(context.Background(), certificateDomain, currentTime)
+}
+
+func (m *Validator) ValidateCertificateContext(ctx context.Context, certificateDomain string, currentTime time.Time) (err error) {
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	_, end := common.StartOperation(ctx, m.instrumentation, common.OperationValidateCertificate)
+	defer func() {
+		info := common.OperationInfo{}
+		if err != nil {
+			info.Reason = string(ReasonFor(err))
+		}
+		end(info, err)
+	}()
+
 	if m.cert == nil {
 		return fmt.Errorf("signingCertificate is required to validate a certificate")
 	}
 
 	// Validate the certificate
-	return certificate.VerifyCertificateWithIntermediates(m.cert, certificateDomain, currentTime, m.intermediateCerts)
+	err = certificate.VerifyCertificateWithIntermediates(m.cert, certificateDomain, currentTime, m.intermediateCerts)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+	return nil
+}
+
+// VerifyTimeAttestation checks the time attestation was signed by the license issuer and returns the attested time.
+func (m *Validator) VerifyTimeAttestation(attestation *common.TimeAttestationEnvelope) (time.Time, error) {
+	return m.VerifyTimeAttestationContext(context.Background(), attestation)
+}
+
+func (m *Validator) VerifyTimeAttestationContext(ctx context.Context, attestation *common.TimeAttestationEnvelope) (time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, err
+	}
+	if m.cert == nil {
+		return time.Time{}, fmt.Errorf("signingCertificate is required to verify a time attestation")
+	}
+
+	if !attestation.IsValid() {
+		return time.Time{}, ErrInvalidTimeAttestation
+	}
+
+	attestationBytes, err := attestation.Attestation.Bytes()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	err = certificate.VerifySignature(m.cert, attestation.Signature, attestationBytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidTimeAttestation, err)
+	}
+
+	issueTime, err := attestation.Attestation.GetIssueTime()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidTimeAttestation, err)
+	}
+	return issueTime, nil
+}
+
+// matchHost checks the fingerprint of a node-locked license against the current host.
+func (m *Validator) matchHost(fingerprint *common.HostFingerprint) error {
+	if fingerprint == nil {
+		return nil
+	}
+	if m.hostFingerprinter == nil {
+		return errors.New("no host fingerprinter is configured")
+	}
+	current, err := m.hostFingerprinter.Fingerprint(fingerprint.FactorNames())
+	if err != nil {
+		return err
+	}
+	return fingerprint.Match(current)
 }
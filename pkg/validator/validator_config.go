@@ -6,6 +6,13 @@ type ValidatorConfig struct {
 	CertPath    string `json:"certPath"`
 	LicensePath string `json:"licensePath"`
 	InstanceID  string `json:"instanceID"`
+	// EnforcementMode is read from the environment, and only applied with ValidationOptions.EnforcementModeFromEnv.
+	// An empty or unknown mode enforces the license.
+	EnforcementMode EnforcementMode `json:"enforcementMode,omitempty"`
+
+	// LicenseSource and CertSource replace reading the license and certificate from LicensePath and CertPath.
+	LicenseSource LicenseSource `json:"-"`
+	CertSource    LicenseSource `json:"-"`
 }
 
 func NewValidatorConfig(instanceID, certPath, licensePath string) *ValidatorConfig {
@@ -23,11 +30,13 @@ func NewValidatorConfig(instanceID, certPath, licensePath string) *ValidatorConf
 }
 
 func NewValidatorConfigFromEnv() *ValidatorConfig {
-	return NewValidatorConfig(
+	config := NewValidatorConfig(
 		os.Getenv(licenseInstanceIDEnv),
 		os.Getenv(licenseCertPathEnv),
 		os.Getenv(licenseFilePathEnv),
 	)
+	config.EnforcementMode = EnforcementMode(os.Getenv(licenseEnforcementModeEnv))
+	return config
 }
 
 func (c *ValidatorConfig) IsValid() bool {
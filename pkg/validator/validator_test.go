@@ -1,12 +1,14 @@
 package validator
 
 import (
+	"context"
 	_ "embed"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/fingerprint"
 	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -111,3 +113,250 @@ func TestManager_ValidateLicense_Invalid(t *testing.T) {
 	err = validator.ValidateLicense(invalidEnvelope, "", "", "", now)
 	assert.Error(t, err)
 }
+
+func TestManager_ValidateLicenseMatchPolicy(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	options := ValidationOptions{
+		SkipCertificateValidation: true,
+		LicenseSource:             NewBytesSource([]byte(envelope.String())),
+		CertSource:                NewBytesSource(certPEM),
+		OrganizationID:            "orgId",
+		ProductPlanUniqueID:       "SKU",
+		InstanceID:                "instance-1",
+		MatchPolicy:               common.StrictMatchPolicy(),
+	}
+	require.NoError(t, ValidateLicenseWithOptions(options))
+
+	// An instance ID left unset no longer disables instance binding
+	validator, err := NewValidatorFromBytes(certPEM, WithMatchPolicy(common.StrictMatchPolicy()))
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateLicense(envelope, "orgId", "SKU", "instance-1", time.Now()))
+	require.ErrorIs(t, validator.ValidateLicense(envelope, "orgId", "SKU", "", time.Now()), ErrLicenseMismatch)
+
+	options.InstanceID = ""
+	options.OrganizationID = ""
+	options.MatchPolicy = &common.MatchPolicy{
+		Required:        []common.IdentityField{common.IdentityFieldOrganization},
+		OrganizationIDs: []string{"previousOrgId", "orgId"},
+	}
+	require.NoError(t, ValidateLicenseWithOptions(options))
+
+	options.MatchPolicy.OrganizationIDs = []string{"previousOrgId"}
+	result := ValidateLicenseWithResult(options)
+	require.ErrorIs(t, result.Err, ErrLicenseMismatch)
+	assert.Equal(t, ReasonMismatch, result.Reason)
+}
+
+func TestManager_ValidateLicenseInstanceIDs(t *testing.T) {
+	t.Parallel()
+
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+	envelope, err := manager.GenerateLicenseFromRequest(generator.NewLicenseRequest("orgId", "SKU", time.Now().Add(time.Hour),
+		generator.WithInstanceID("instance-primary"),
+		generator.WithInstanceIDs("instance-dr", "fleet-*")))
+	require.NoError(t, err)
+
+	validator, err := NewValidatorFromBytes(certPEM)
+	require.NoError(t, err)
+	for _, instanceID := range []string{"instance-primary", "instance-dr", "fleet-eu-1"} {
+		require.NoError(t, validator.ValidateLicenseBase64(envelope.EncodeBase64(), "orgId", "SKU", instanceID, time.Now()), instanceID)
+	}
+	require.ErrorIs(t, validator.ValidateLicense(envelope, "orgId", "SKU", "instance-other", time.Now()), ErrLicenseMismatch)
+
+	// The instance IDs are covered by the signature
+	envelope.License.InstanceIDs = append(envelope.License.InstanceIDs, "instance-other")
+	require.ErrorIs(t, validator.ValidateLicense(envelope, "orgId", "SKU", "instance-other", time.Now()), ErrInvalidSignature)
+}
+
+func TestManager_ValidateLicenseHostFingerprint(t *testing.T) {
+	t.Parallel()
+
+	host := func(machineID, cpu string) *fingerprint.Fingerprinter {
+		return fingerprint.New(
+			fingerprint.WithRoot(t.TempDir()),
+			fingerprint.WithSource(fingerprint.FactorMachineID, func(string) (string, error) { return machineID, nil }),
+			fingerprint.WithSource(fingerprint.FactorCPU, func(string) (string, error) { return cpu, nil }))
+	}
+	binding, err := host("machine-1", "cpu-1").Binding(1, fingerprint.FactorMachineID, fingerprint.FactorCPU)
+	require.NoError(t, err)
+
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+	envelope, err := manager.GenerateLicenseFromRequest(generator.NewLicenseRequest("orgId", "SKU", time.Now().Add(time.Hour),
+		generator.WithHostFingerprint(binding)))
+	require.NoError(t, err)
+
+	// One of the two factors is enough to match
+	for _, fingerprinter := range []*fingerprint.Fingerprinter{host("machine-1", "cpu-1"), host("machine-1", "cpu-2")} {
+		validator, err := NewValidatorFromBytes(certPEM, WithHostFingerprinter(fingerprinter))
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateLicense(envelope, "orgId", "SKU", "", time.Now()))
+	}
+
+	validator, err := NewValidatorFromBytes(certPEM, WithHostFingerprinter(host("machine-2", "cpu-2")))
+	require.NoError(t, err)
+	err = validator.ValidateLicense(envelope, "orgId", "SKU", "", time.Now())
+	require.ErrorIs(t, err, ErrLicenseMismatch)
+	require.ErrorIs(t, err, ErrHostFingerprintMismatch)
+	assert.Equal(t, ReasonMismatch, ReasonFor(err))
+
+	// Validators built from a config take the fingerprinter too
+	for _, config := range []*ValidatorConfig{{CertSource: NewBytesSource(certPEM)}, {CertPath: "certificate-test-tls.crt"}} {
+		validator, err = NewValidatorFromConfig(config, WithHostFingerprinter(host("machine-1", "cpu-1")))
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateLicense(envelope, "orgId", "SKU", "", time.Now()))
+	}
+
+	// A node-locked license fails closed without a fingerprinter
+	validator, err = NewValidatorFromBytes(certPEM)
+	require.NoError(t, err)
+	require.ErrorIs(t, validator.ValidateLicense(envelope, "orgId", "SKU", "", time.Now()), ErrHostFingerprintMismatch)
+
+	// The fingerprint is covered by the signature
+	envelope.License.HostFingerprint = nil
+	require.ErrorIs(t, validator.ValidateLicense(envelope, "orgId", "SKU", "", time.Now()), ErrInvalidSignature)
+}
+
+func TestManager_SelectLatestLicense(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+
+	first, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(48*time.Hour))
+	require.NoError(t, err)
+
+	plan := "SKU-PRO"
+	upgraded, err := manager.AmendLicense(first, generator.LicenseAmendment{ProductPlanUniqueID: &plan})
+	require.NoError(t, err)
+
+	renewed, err := manager.RenewLicense(upgraded, now.Add(96*time.Hour))
+	require.NoError(t, err)
+
+	tampered := &common.LicenseEnvelope{License: renewed.License.Clone(), Signature: renewed.Signature}
+	tampered.License.Version++
+
+	licenseValidator, err := NewValidatorFromBytes(certPEM)
+	require.NoError(t, err)
+	validator, ok := licenseValidator.(ContextValidator)
+	require.True(t, ok)
+
+	latest, err := validator.SelectLatestLicense([]*common.LicenseEnvelope{first, renewed, nil, tampered, upgraded}, "orgId", "", "instance-1", now)
+	require.NoError(t, err)
+	assert.Equal(t, renewed, latest)
+
+	// Only licenses matching the expected plan are considered
+	latest, err = validator.SelectLatestLicense([]*common.LicenseEnvelope{first, renewed, upgraded}, "orgId", "SKU", "instance-1", now)
+	require.NoError(t, err)
+	assert.Equal(t, first, latest)
+
+	// Expired licenses are skipped
+	_, err = validator.SelectLatestLicense([]*common.LicenseEnvelope{first, upgraded}, "orgId", "", "instance-1", now.Add(72*time.Hour))
+	assert.Error(t, err)
+
+	// Licenses from different lineages are rejected
+	other, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-2", "product a", now.Add(48*time.Hour))
+	require.NoError(t, err)
+	_, err = validator.SelectLatestLicense([]*common.LicenseEnvelope{first, other}, "orgId", "SKU", "instance-1", now)
+	assert.Error(t, err)
+
+	_, err = validator.SelectLatestLicense(nil, "orgId", "SKU", "instance-1", now)
+	assert.Error(t, err)
+}
+
+func TestManager_ValidateLicenseNotBefore(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+
+	envelope, err := manager.GenerateLicenseFromRequest(generator.NewLicenseRequest(
+		"orgId",
+		"SKU",
+		now.Add(48*time.Hour),
+		generator.WithInstanceID("instance-1"),
+		generator.WithNotBefore(now.Add(24*time.Hour)),
+	))
+	require.NoError(t, err)
+
+	validator, err := NewValidatorFromBytes(certPEM)
+	require.NoError(t, err)
+
+	err = validator.ValidateLicense(envelope, "orgId", "SKU", "instance-1", now)
+	assert.Error(t, err)
+
+	err = validator.ValidateLicense(envelope, "orgId", "SKU", "instance-1", now.Add(25*time.Hour))
+	assert.NoError(t, err)
+}
+
+func TestManager_Context(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	licenseGenerator, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	manager, ok := licenseGenerator.(generator.ContextGenerator)
+	require.True(t, ok)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(48*time.Hour))
+	require.NoError(t, err)
+	attestation, err := manager.IssueTimeAttestation()
+	require.NoError(t, err)
+
+	licenseValidator, err := NewValidatorFromBytes(certPEM)
+	require.NoError(t, err)
+	validator, ok := licenseValidator.(ContextValidator)
+	require.True(t, ok)
+	require.NoError(t, validator.ValidateLicenseContext(context.Background(), envelope, "orgId", "SKU", "instance-1", now))
+
+	// A cancelled context fails every validation, even of a valid license
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, validator.ValidateLicenseContext(ctx, envelope, "orgId", "SKU", "instance-1", now), context.Canceled)
+	require.ErrorIs(t, validator.ValidateLicenseStringContext(ctx, envelope.String(), "orgId", "SKU", "instance-1", now), context.Canceled)
+	require.ErrorIs(t, validator.ValidateLicenseBytesContext(ctx, []byte(envelope.String()), "orgId", "SKU", "instance-1", now), context.Canceled)
+	require.ErrorIs(t, validator.ValidateLicenseBase64Context(ctx, envelope.EncodeBase64(), "orgId", "SKU", "instance-1", now), context.Canceled)
+	require.ErrorIs(t, validator.ValidateCertificateContext(ctx, "licensing.omnistrate.cloud", now), context.Canceled)
+	_, err = validator.SelectLatestLicenseContext(ctx, []*common.LicenseEnvelope{envelope}, "orgId", "SKU", "instance-1", now)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = validator.VerifyTimeAttestationContext(ctx, attestation)
+	require.ErrorIs(t, err, context.Canceled)
+
+	options := ValidationOptions{
+		SkipCertificateValidation: true,
+		LicenseSource:             NewBytesSource([]byte(envelope.String())),
+		CertSource:                NewBytesSource(certPEM),
+		OrganizationID:            "orgId",
+		ProductPlanUniqueID:       "SKU",
+		InstanceID:                "instance-1",
+		EnforcementMode:           EnforcementModeAudit,
+	}
+	require.NoError(t, ValidateLicenseWithOptionsContext(context.Background(), options))
+	// Cancellation is reported whatever the enforcement mode
+	require.ErrorIs(t, ValidateLicenseWithOptionsContext(ctx, options), context.Canceled)
+	assert.Equal(t, ReasonUnavailable, ValidateLicenseWithResultContext(ctx, options).Reason)
+
+	// A cancelled check leaves the watcher status unchanged
+	watcher := NewWatcher(WatcherOptions{ValidationOptions: options})
+	valid := watcher.Check()
+	assert.Same(t, valid, watcher.CheckContext(ctx))
+	assert.Same(t, valid, watcher.Status())
+}
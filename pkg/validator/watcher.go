@@ -0,0 +1,311 @@
+package validator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultWatcherInterval              = time.Minute
+	defaultWatcherPollInterval          = 5 * time.Second
+	defaultWatcherExpiringSoonThreshold = 24 * time.Hour
+)
+
+type LicenseState string
+
+const (
+	LicenseStateUnknown      LicenseState = "unknown"
+	LicenseStateValid        LicenseState = "valid"
+	LicenseStateExpiringSoon LicenseState = "expiring_soon"
+	LicenseStateExpired      LicenseState = "expired"
+	LicenseStateInvalid      LicenseState = "invalid"
+)
+
+// LicenseStatus is the outcome of the latest validation performed by a Watcher.
+type LicenseStatus struct {
+	State  LicenseState
+	Reason Reason
+	// Mode is the enforcement mode configured for the watcher. Consumers such as middlewares only reject
+	// requests for invalid licenses in enforce mode.
+	Mode EnforcementMode
+	// Envelope is the license that was validated. It is nil when the license could not be read or decoded, and
+	// its claims are only trustworthy when Verified is set. Use License to read them.
+	Envelope *common.LicenseEnvelope
+	// Verified reports whether the signature of Envelope was verified against a trusted signing certificate,
+	// even when the license is not valid for other reasons such as being expired.
+	Verified  bool
+	Err       error
+	CheckedAt time.Time
+	// CertificateExpiresAt is the expiration of the signing certificate, or zero when it could not be read.
+	CertificateExpiresAt time.Time
+	// InstanceID is the instance ID the license was matched against and where it came from.
+	InstanceID DiscoveredInstanceID
+}
+
+// IsValid reports whether the license passed validation, including licenses that are about to expire.
+func (s *LicenseStatus) IsValid() bool {
+	return s != nil && (s.State == LicenseStateValid || s.State == LicenseStateExpiringSoon)
+}
+
+// IsEnforced reports whether an invalid license must be rejected, which is the case unless the watcher
+// runs in warn or audit mode and the failure can be relaxed.
+func (s *LicenseStatus) IsEnforced() bool {
+	return s == nil || s.Mode == "" || s.Mode == EnforcementModeEnforce || (!s.IsValid() && !s.Reason.IsRelaxable())
+}
+
+// License returns the validated license, or nil when the license could not be read or decoded or its signature
+// was not verified. Claims of unverified licenses must not be trusted, whatever the enforcement mode.
+func (s *LicenseStatus) License() *common.License {
+	if s == nil || s.Envelope == nil || !s.Verified {
+		return nil
+	}
+	return s.Envelope.License
+}
+
+func (s *LicenseStatus) ExpiresAt() time.Time {
+	license := s.License()
+	if license == nil {
+		return time.Time{}
+	}
+	expirationTime, _ := license.GetExpirationTime()
+	return expirationTime
+}
+
+// WatcherOptions configures a Watcher. Callbacks are invoked synchronously from the watcher goroutine
+// when the license state changes, and must not block. They run after the check completed, and may call Check.
+type WatcherOptions struct {
+	ValidationOptions
+
+	// Interval is how often the license is re-validated. Defaults to one minute.
+	Interval time.Duration
+	// PollInterval is how often the license and certificate files are checked for changes. Defaults to five seconds.
+	// Sources that do not implement VersionedSource are only re-validated every Interval.
+	PollInterval time.Duration
+	// ExpiringSoonThreshold is how long before expiration a valid license is reported as expiring soon. Defaults to 24 hours.
+	ExpiringSoonThreshold time.Duration
+
+	OnValid        func(status *LicenseStatus)
+	OnExpiringSoon func(status *LicenseStatus)
+	OnExpired      func(status *LicenseStatus)
+	OnInvalid      func(status *LicenseStatus)
+	// OnRenewed is invoked when a different valid license replaces the previously valid one.
+	OnRenewed func(previous, current *LicenseStatus)
+	// OnCheck is invoked after every validation, whether or not the state changed.
+	OnCheck func(status *LicenseStatus)
+}
+
+// Watcher periodically re-validates the license and exposes the latest status.
+type Watcher struct {
+	options WatcherOptions
+	status  atomic.Pointer[LicenseStatus]
+	updates chan struct{}
+	mode    EnforcementMode
+
+	mu          sync.Mutex
+	lastVersion string
+	lastValid   *LicenseStatus
+}
+
+func NewWatcher(options WatcherOptions) *Watcher {
+	if options.Interval <= 0 {
+		options.Interval = defaultWatcherInterval
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultWatcherPollInterval
+	}
+	if options.ExpiringSoonThreshold <= 0 {
+		options.ExpiringSoonThreshold = defaultWatcherExpiringSoonThreshold
+	}
+
+	w := &Watcher{
+		options: options,
+		updates: make(chan struct{}, 1),
+		mode:    options.enforcementMode(options.config()),
+	}
+	w.status.Store(&LicenseStatus{State: LicenseStateUnknown})
+	return w
+}
+
+// Status returns the latest license status. It is safe for concurrent use.
+func (w *Watcher) Status() *LicenseStatus {
+	return w.status.Load()
+}
+
+// Start validates the license once and keeps watching it in the background until the context is done.
+func (w *Watcher) Start(ctx context.Context) *LicenseStatus {
+	status := w.CheckContext(ctx)
+	go func() {
+		_ = w.run(ctx)
+	}()
+	return status
+}
+
+// Run validates the license and keeps watching it until the context is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	w.CheckContext(ctx)
+	return w.run(ctx)
+}
+
+func (w *Watcher) run(ctx context.Context) error {
+	interval := time.NewTicker(w.options.Interval)
+	defer interval.Stop()
+	poll := time.NewTicker(w.options.PollInterval)
+	defer poll.Stop()
+	w.watchSources(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-interval.C:
+			w.CheckContext(ctx)
+		case <-poll.C:
+			if w.changed(ctx) {
+				w.CheckContext(ctx)
+			}
+		case <-w.updates:
+			w.CheckContext(ctx)
+		}
+	}
+}
+
+// watchSources subscribes to the configured sources that push their updates.
+func (w *Watcher) watchSources(ctx context.Context) {
+	config := w.options.config()
+	for _, source := range []LicenseSource{config.LicenseSource, config.CertSource} {
+		watchable, ok := source.(WatchableSource)
+		if !ok {
+			continue
+		}
+		go func() {
+			// Sources that fail to watch are still re-validated every interval
+			_ = watchable.Watch(ctx, w.notifyUpdate)
+		}()
+	}
+}
+
+func (w *Watcher) notifyUpdate() {
+	select {
+	case w.updates <- struct{}{}:
+	default:
+		// A check is already pending
+	}
+}
+
+// Check re-validates the license now, updates the status and fires callbacks for state changes.
+func (w *Watcher) Check() *LicenseStatus {
+	return w.CheckContext(context.Background())
+}
+
+// CheckContext is Check, loading the license with the context. A check interrupted by the context leaves
+// the status unchanged and returns it.
+func (w *Watcher) CheckContext(ctx context.Context) *LicenseStatus {
+	status, callbacks := w.check(ctx)
+	// Callbacks run once the watcher is unlocked, so they may check the license again
+	for _, callback := range callbacks {
+		callback()
+	}
+	return status
+}
+
+// check validates the license and returns the new status with the callbacks to fire for it.
+func (w *Watcher) check(ctx context.Context) (*LicenseStatus, []func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	version := w.version(ctx)
+
+	currentTime := w.options.CurrentTime
+	if currentTime.IsZero() {
+		currentTime = time.Now().UTC()
+	}
+	options := w.options.ValidationOptions
+	options.CurrentTime = currentTime
+
+	validated, err := validateLicenseWithOptions(ctx, options)
+	if ctx.Err() != nil {
+		return w.status.Load(), nil
+	}
+	w.lastVersion = version
+	status := &LicenseStatus{
+		Reason:               validationReason(validated.envelope, err),
+		Mode:                 w.mode,
+		Envelope:             validated.envelope,
+		Verified:             validated.verified,
+		Err:                  err,
+		CheckedAt:            currentTime,
+		CertificateExpiresAt: validated.certExpiresAt,
+		InstanceID:           validated.instanceID,
+	}
+	switch {
+	case err == nil && currentTime.Add(w.options.ExpiringSoonThreshold).After(status.ExpiresAt()):
+		status.State = LicenseStateExpiringSoon
+	case err == nil:
+		status.State = LicenseStateValid
+	case errors.Is(err, ErrLicenseExpired):
+		status.State = LicenseStateExpired
+	default:
+		status.State = LicenseStateInvalid
+	}
+
+	previous := w.status.Swap(status)
+	var callbacks []func()
+	if onCheck := w.options.OnCheck; onCheck != nil {
+		callbacks = append(callbacks, func() { onCheck(status) })
+	}
+	return status, append(callbacks, w.notify(previous, status)...)
+}
+
+// notify returns the callbacks to fire for the state change from previous to current.
+func (w *Watcher) notify(previous, current *LicenseStatus) []func() {
+	var callbacks []func()
+	if current.IsValid() {
+		if lastValid, onRenewed := w.lastValid, w.options.OnRenewed; lastValid != nil && onRenewed != nil && !sameEnvelope(lastValid.Envelope, current.Envelope) {
+			callbacks = append(callbacks, func() { onRenewed(lastValid, current) })
+		}
+		w.lastValid = current
+	}
+
+	if previous.State == current.State {
+		return callbacks
+	}
+
+	var callback func(status *LicenseStatus)
+	switch current.State {
+	case LicenseStateValid:
+		callback = w.options.OnValid
+	case LicenseStateExpiringSoon:
+		callback = w.options.OnExpiringSoon
+	case LicenseStateExpired:
+		callback = w.options.OnExpired
+	case LicenseStateInvalid:
+		callback = w.options.OnInvalid
+	}
+	if callback != nil {
+		callbacks = append(callbacks, func() { callback(current) })
+	}
+	return callbacks
+}
+
+func sameEnvelope(a, b *common.LicenseEnvelope) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return string(a.Signature) == string(b.Signature)
+}
+
+// changed reports whether the license or certificate files changed since the last check.
+func (w *Watcher) changed(ctx context.Context) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.version(ctx) != w.lastVersion
+}
+
+func (w *Watcher) version(ctx context.Context) string {
+	return licenseFilesVersionFromConfig(ctx, w.options.config())
+}
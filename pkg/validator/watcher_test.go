@@ -0,0 +1,236 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/certificate"
+	"github.com/omnistrate-oss/omnistrate-licensing-sdk-go/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLicenseFiles(t *testing.T, dir string, license string) (string, string) {
+	t.Helper()
+
+	licensePath := filepath.Join(dir, "license.lic")
+	certPath := filepath.Join(dir, "license.crt")
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(licensePath+".tmp", []byte(license), 0o600))
+	require.NoError(t, os.Rename(licensePath+".tmp", licensePath))
+	return licensePath, certPath
+}
+
+// receive waits for a value from the watcher, failing the test when it does not come.
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for the watcher")
+	}
+	var zero T
+	return zero
+}
+
+func TestWatcher(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM, generator.WithRenewalPolicy(generator.RenewalPolicy{AllowShortening: true}))
+	require.NoError(t, err)
+
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", now.Add(48*time.Hour))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	licensePath, certPath := writeLicenseFiles(t, dir, envelope.String())
+
+	events := make(chan LicenseState, 10)
+	renewed := make(chan *LicenseStatus, 10)
+	watcher := NewWatcher(WatcherOptions{
+		ValidationOptions: ValidationOptions{
+			SkipCertificateValidation: true,
+			CertPath:                  certPath,
+			LicensePath:               licensePath,
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+		},
+		Interval:       time.Hour,
+		PollInterval:   10 * time.Millisecond,
+		OnValid:        func(status *LicenseStatus) { events <- status.State },
+		OnExpiringSoon: func(status *LicenseStatus) { events <- status.State },
+		OnExpired:      func(status *LicenseStatus) { events <- status.State },
+		OnInvalid:      func(status *LicenseStatus) { events <- status.State },
+		OnRenewed:      func(previous, current *LicenseStatus) { renewed <- current },
+	})
+	assert.Equal(t, LicenseStateUnknown, watcher.Status().State)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- watcher.Run(ctx)
+	}()
+
+	assert.Equal(t, LicenseStateValid, receive(t, events))
+	status := watcher.Status()
+	assert.True(t, status.IsValid())
+	assert.Equal(t, envelope.License.ID, status.License().ID)
+	expiresAt, _ := envelope.License.GetExpirationTime()
+	assert.Equal(t, expiresAt, status.ExpiresAt())
+
+	// A renewed license is picked up on file change
+	renewedEnvelope, err := manager.RenewLicense(envelope, now.Add(72*time.Hour))
+	require.NoError(t, err)
+	writeLicenseFiles(t, dir, renewedEnvelope.String())
+	current := receive(t, renewed)
+	assert.Equal(t, renewedEnvelope.License.Version, current.License().Version)
+	assert.Equal(t, LicenseStateValid, watcher.Status().State)
+
+	// A license about to expire
+	expiringEnvelope, err := manager.RenewLicense(renewedEnvelope, now.Add(time.Hour))
+	require.NoError(t, err)
+	writeLicenseFiles(t, dir, expiringEnvelope.String())
+	assert.Equal(t, LicenseStateExpiringSoon, receive(t, events))
+	receive(t, renewed)
+
+	// An expired license
+	expiredEnvelope, err := manager.RenewLicense(expiringEnvelope, now.Add(-time.Hour))
+	require.NoError(t, err)
+	writeLicenseFiles(t, dir, expiredEnvelope.String())
+	assert.Equal(t, LicenseStateExpired, receive(t, events))
+	assert.ErrorIs(t, watcher.Status().Err, ErrLicenseExpired)
+	assert.NotNil(t, watcher.Status().License())
+
+	// A corrupted license
+	writeLicenseFiles(t, dir, "not a license")
+	assert.Equal(t, LicenseStateInvalid, receive(t, events))
+	assert.False(t, watcher.Status().IsValid())
+	assert.Nil(t, watcher.Status().License())
+
+	// Recovery
+	writeLicenseFiles(t, dir, renewedEnvelope.String())
+	assert.Equal(t, LicenseStateValid, receive(t, events))
+
+	cancel()
+	assert.NoError(t, receive(t, done))
+}
+
+func TestWatcher_Start(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := NewWatcher(WatcherOptions{
+		ValidationOptions: ValidationOptions{
+			SkipCertificateValidation: true,
+			CertPath:                  filepath.Join(t.TempDir(), "missing.crt"),
+			LicensePath:               filepath.Join(t.TempDir(), "missing.lic"),
+		},
+	})
+
+	status := watcher.Start(ctx)
+	assert.Equal(t, LicenseStateInvalid, status.State)
+	assert.Error(t, status.Err)
+	assert.Same(t, status, watcher.Status())
+	assert.True(t, status.CertificateExpiresAt.IsZero())
+}
+
+func TestWatcher_OnCheck(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(48*time.Hour))
+	require.NoError(t, err)
+
+	var checks []*LicenseStatus
+	watcher := NewWatcher(WatcherOptions{
+		ValidationOptions: ValidationOptions{
+			SkipCertificateValidation: true,
+			LicenseSource:             NewBytesSource([]byte(envelope.String())),
+			CertSource:                NewBytesSource(certPEM),
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+		},
+		OnCheck: func(status *LicenseStatus) { checks = append(checks, status) },
+	})
+
+	// Every check is reported, even when the state does not change
+	first := watcher.Check()
+	second := watcher.Check()
+	assert.Equal(t, []*LicenseStatus{first, second}, checks)
+	assert.Equal(t, LicenseStateValid, second.State)
+
+	cert, err := certificate.LoadCertificateChainFromBytes(certPEM)
+	require.NoError(t, err)
+	assert.Equal(t, cert[0].NotAfter, second.CertificateExpiresAt)
+}
+
+func TestWatcher_UnverifiedLicense(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(48*time.Hour))
+	require.NoError(t, err)
+
+	// A license whose claims were changed after signing is not exposed, even in warn mode
+	envelope.License.Features = []string{"sso"}
+	watcher := NewWatcher(WatcherOptions{
+		ValidationOptions: ValidationOptions{
+			SkipCertificateValidation: true,
+			LicenseSource:             NewBytesSource([]byte(envelope.String())),
+			CertSource:                NewBytesSource(certPEM),
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+			EnforcementMode:           EnforcementModeWarn,
+		},
+	})
+
+	status := watcher.Check()
+	assert.Equal(t, LicenseStateInvalid, status.State)
+	assert.ErrorIs(t, status.Err, ErrInvalidSignature)
+	assert.NotNil(t, status.Envelope)
+	assert.False(t, status.Verified)
+	assert.Nil(t, status.License())
+	assert.True(t, status.IsEnforced())
+}
+
+func TestWatcher_CallbackChecks(t *testing.T) {
+	t.Parallel()
+
+	manager, err := generator.NewGeneratorFromBytes(keyPEM, certPEM)
+	require.NoError(t, err)
+	envelope, err := manager.GenerateLicense("orgId", "SKU", "instance-1", "subs-1", "product a", time.Now().Add(48*time.Hour))
+	require.NoError(t, err)
+
+	// A callback checking the license again does not deadlock
+	var watcher *Watcher
+	rechecked := make(chan *LicenseStatus, 1)
+	watcher = NewWatcher(WatcherOptions{
+		ValidationOptions: ValidationOptions{
+			SkipCertificateValidation: true,
+			LicenseSource:             NewBytesSource([]byte(envelope.String())),
+			CertSource:                NewBytesSource(certPEM),
+			OrganizationID:            "orgId",
+			ProductPlanUniqueID:       "SKU",
+			InstanceID:                "instance-1",
+		},
+		OnValid: func(*LicenseStatus) { rechecked <- watcher.Check() },
+	})
+
+	done := make(chan *LicenseStatus, 1)
+	go func() { done <- watcher.Check() }()
+	assert.Equal(t, LicenseStateValid, receive(t, done).State)
+	assert.Same(t, receive(t, rechecked), watcher.Status())
+}